@@ -0,0 +1,261 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bump
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/buildpacks/github-actions/buildpack/internal/descriptor"
+	"github.com/buildpacks/github-actions/internal/toolkit"
+)
+
+func BumpVersion(tk toolkit.Toolkit) error {
+	c, err := parseConfig(tk)
+	if err != nil {
+		return err
+	}
+
+	d, err := descriptor.Read(c.Path, c.Kind)
+	if err != nil {
+		return toolkit.FailedError(err)
+	}
+	info := d.Info()
+
+	current, err := semver.StrictNewVersion(info.Version)
+	if err != nil {
+		return toolkit.FailedErrorf("version %s of %s must be a semantic version", info.Version, info.ID)
+	}
+
+	increment := c.Increment
+	if increment == "" {
+		if increment, err = commitIncrement(tk, c, d, current); err != nil {
+			return err
+		}
+	}
+
+	tk.SetOutput("previous-version", current.String())
+	tk.SetOutput("increment", increment)
+
+	if increment == "" {
+		fmt.Printf("No features, fixes, or breaking changes since %s@%s\n", info.ID, current)
+		tk.SetOutput("version", current.String())
+		tk.SetOutput("bumped", "false")
+		tk.SetOutput("updated", "[]")
+		return nil
+	}
+
+	next := Next(*current, increment, c.PreReleaseIdentifier)
+
+	e, err := descriptor.Prepare(d.Path, func(descriptor.Values) (map[string]string, error) {
+		return map[string]string{fmt.Sprintf("%s.version", d.Kind): next.String()}, nil
+	})
+	if err != nil {
+		return err
+	}
+	edits := []descriptor.Edit{e}
+
+	descriptors, err := descriptor.Discover(c.Directory, descriptor.KindBuildpack)
+	if err != nil {
+		return toolkit.FailedError(err)
+	}
+
+	references := map[string]int{}
+	for _, m := range descriptors {
+		if same(m.Path, d.Path) {
+			continue
+		}
+
+		replacements := map[string]string{}
+		for i, o := range m.Order {
+			for j, g := range o.Groups {
+				if g.ID == info.ID && g.Version == info.Version {
+					replacements[fmt.Sprintf("order[%d].group[%d].version", i, j)] = next.String()
+				}
+			}
+		}
+
+		if len(replacements) == 0 {
+			continue
+		}
+
+		e, err := descriptor.Prepare(m.Path, func(descriptor.Values) (map[string]string, error) {
+			return replacements, nil
+		})
+		if err != nil {
+			return err
+		}
+		edits = append(edits, e)
+		references[m.Path] = len(replacements)
+	}
+
+	var updated []string
+	for _, e := range edits {
+		if err := e.Write(); err != nil {
+			return err
+		}
+		updated = append(updated, e.Path)
+
+		if n, ok := references[e.Path]; ok {
+			fmt.Printf("Updated %d references to %s in %s\n", n, info.ID, e.Path)
+		} else {
+			fmt.Printf("Bumped %s from %s to %s in %s\n", info.ID, current, next, e.Path)
+		}
+	}
+
+	b, err := json.Marshal(updated)
+	if err != nil {
+		return toolkit.FailedErrorf("unable to marshal updated\n%w", err)
+	}
+
+	tk.SetOutput("version", next.String())
+	tk.SetOutput("bumped", "true")
+	tk.SetOutput("updated", string(b))
+
+	return nil
+}
+
+// Next returns the version after v for an increment.  A pre-release increment bumps the numeric suffix of a
+// pre-release with the same identifier, or starts the first pre-release of the next patch version.
+func Next(v semver.Version, increment string, identifier string) semver.Version {
+	switch increment {
+	case IncrementMajor:
+		return v.IncMajor()
+	case IncrementMinor:
+		return v.IncMinor()
+	case IncrementPatch:
+		return v.IncPatch()
+	}
+
+	if s, ok := strings.CutPrefix(v.Prerelease(), identifier+"."); ok {
+		if n, err := strconv.Atoi(s); err == nil {
+			next, _ := v.SetPrerelease(fmt.Sprintf("%s.%d", identifier, n+1))
+			return next
+		}
+	}
+
+	if v.Prerelease() == "" {
+		v = v.IncPatch()
+	}
+	next, _ := v.SetPrerelease(fmt.Sprintf("%s.1", identifier))
+	return next
+}
+
+func commitIncrement(tk toolkit.Toolkit, c config, d descriptor.Descriptor, current *semver.Version) (string, error) {
+	since := c.Since
+	if since == "" {
+		t, err := Tag(c.Repository, tagNames(c, d, current)...)
+		if err != nil {
+			return "", toolkit.FailedError(err)
+		}
+
+		if t == "" {
+			tk.Warningf("no tag found for %s@%s, computing increment from every commit", d.Info().ID, current)
+		}
+		since = t
+	}
+
+	messages, err := Messages(c.Repository, since)
+	if err != nil {
+		return "", toolkit.FailedError(err)
+	}
+
+	if since == "" {
+		fmt.Printf("Found %d commits\n", len(messages))
+	} else {
+		fmt.Printf("Found %d commits since %s\n", len(messages), since)
+	}
+
+	return Increment(messages), nil
+}
+
+// tagNames returns the names a tag of the current version may have, with the id or the directory of the descriptor
+// relative to directory as prefix for repositories with many buildpacks, e.g. test-name/v1.2.3, before v1.2.3.
+func tagNames(c config, d descriptor.Descriptor, current *semver.Version) []string {
+	prefixes := []string{d.Info().ID}
+	if dir, err := filepath.Rel(c.Directory, filepath.Dir(d.Path)); err == nil && dir != "." && !strings.HasPrefix(dir, "..") {
+		prefixes = append(prefixes, filepath.ToSlash(dir))
+	}
+
+	var names []string
+	for _, p := range prefixes {
+		names = append(names, fmt.Sprintf("%s/v%s", p, current), fmt.Sprintf("%s/%s", p, current))
+	}
+
+	return append(names, fmt.Sprintf("v%s", current), current.String())
+}
+
+func same(a string, b string) bool {
+	a, errA := filepath.Abs(a)
+	b, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && a == b
+}
+
+type config struct {
+	descriptor.Config
+
+	Increment            string
+	PreReleaseIdentifier string
+	Repository           string
+	Since                string
+	Directory            string
+}
+
+func parseConfig(tk toolkit.Toolkit) (config, error) {
+	var c config
+
+	d, err := descriptor.ParseConfig(tk)
+	if err != nil {
+		return config{}, err
+	}
+	c.Config = d
+
+	if s, ok := tk.GetInput("increment"); ok && s != "" {
+		switch s {
+		case IncrementMajor, IncrementMinor, IncrementPatch, IncrementPreRelease:
+			c.Increment = s
+		default:
+			return config{}, toolkit.FailedErrorf("invalid increment %s, must be %s, %s, %s, or %s", s, IncrementMajor, IncrementMinor, IncrementPatch, IncrementPreRelease)
+		}
+	}
+
+	c.PreReleaseIdentifier = "rc"
+	if s, ok := tk.GetInput("pre-release-identifier"); ok && s != "" {
+		c.PreReleaseIdentifier = s
+	}
+
+	c.Repository = "."
+	if s, ok := tk.GetInput("repository"); ok && s != "" {
+		c.Repository = s
+	}
+
+	if s, ok := tk.GetInput("since"); ok {
+		c.Since = s
+	}
+
+	c.Directory = "."
+	if s, ok := tk.GetInput("directory"); ok && s != "" {
+		c.Directory = s
+	}
+
+	return c, nil
+}
@@ -0,0 +1,266 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bump_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/stretchr/testify/mock"
+
+	bump "github.com/buildpacks/github-actions/buildpack/bump-version"
+	"github.com/buildpacks/github-actions/internal/testutil"
+	"github.com/buildpacks/github-actions/internal/toolkit"
+)
+
+func TestBumpVersion(t *testing.T) {
+	spec.Run(t, "bump-version", func(t *testing.T, context spec.G, it spec.S) {
+		var (
+			Expect = NewWithT(t).Expect
+
+			dir  string
+			path string
+			meta string
+			repo *git.Repository
+			tk   = &toolkit.MockToolkit{}
+		)
+
+		read := func(path string) string {
+			b, err := os.ReadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			return string(b)
+		}
+
+		commit := func(message string) {
+			w, err := repo.Worktree()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(w.AddGlob(".")).To(Succeed())
+
+			_, err = w.Commit(message, &git.CommitOptions{
+				AllowEmptyCommits: true,
+				Author:            &object.Signature{Name: "test-name", Email: "test@example.com", When: time.Now()},
+			})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		it.Before(func() {
+			var err error
+
+			dir = t.TempDir()
+			repo, err = git.PlainInit(dir, false)
+			Expect(err).NotTo(HaveOccurred())
+
+			path = filepath.Join(dir, "test-name", "buildpack.toml")
+			Expect(os.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+			Expect(os.WriteFile(path, []byte(`api = "0.8"
+
+# the buildpack
+[buildpack]
+id      = "test-namespace/test-name"
+version = "1.2.3" # released version
+
+[[stacks]]
+id = "*"
+`), 0644)).To(Succeed())
+
+			meta = filepath.Join(dir, "test-meta", "buildpack.toml")
+			Expect(os.MkdirAll(filepath.Dir(meta), 0755)).To(Succeed())
+			Expect(os.WriteFile(meta, []byte(`api = "0.8"
+
+[buildpack]
+id      = "test-namespace/test-meta"
+version = "1.2.3"
+
+[[order]]
+[[order.group]]
+id      = "test-namespace/test-name"
+version = "1.2.3"
+
+[[order.group]]
+id      = "test-namespace/other"
+version = "1.2.3"
+
+[[order]]
+[[order.group]]
+id      = "test-namespace/test-name"
+version = "1.0.0"
+`), 0644)).To(Succeed())
+
+			commit("feat!: initial release")
+			head, err := repo.Head()
+			Expect(err).NotTo(HaveOccurred())
+			_, err = repo.CreateTag("v1.2.3", head.Hash(), &git.CreateTagOptions{
+				Tagger:  &object.Signature{Name: "test-name", Email: "test@example.com", When: time.Now()},
+				Message: "v1.2.3",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			tk.On("GetInput", "kind").Return("", false)
+			tk.On("GetInput", "path").Return(path, true)
+			tk.On("GetInput", "pre-release-identifier").Return("", false)
+			tk.On("GetInput", "repository").Return(dir, true)
+			tk.On("GetInput", "since").Return("", false)
+			tk.On("GetInput", "directory").Return(dir, true)
+			tk.On("SetOutput", mock.Anything, mock.Anything)
+		})
+
+		context("from commits", func() {
+			it.Before(func() {
+				tk.On("GetInput", "increment").Return("", false)
+			})
+
+			it("bumps version and order group references", func() {
+				commit("fix: handle empty file")
+				commit("feat(build): add option")
+				commit("docs: describe option")
+
+				Expect(bump.BumpVersion(tk)).To(Succeed())
+
+				Expect(read(path)).To(Equal(`api = "0.8"
+
+# the buildpack
+[buildpack]
+id      = "test-namespace/test-name"
+version = "1.3.0" # released version
+
+[[stacks]]
+id = "*"
+`))
+				Expect(read(meta)).To(Equal(`api = "0.8"
+
+[buildpack]
+id      = "test-namespace/test-meta"
+version = "1.2.3"
+
+[[order]]
+[[order.group]]
+id      = "test-namespace/test-name"
+version = "1.3.0"
+
+[[order.group]]
+id      = "test-namespace/other"
+version = "1.2.3"
+
+[[order]]
+[[order.group]]
+id      = "test-namespace/test-name"
+version = "1.0.0"
+`))
+
+				Expect(testutil.Output(tk, "previous-version")).To(Equal("1.2.3"))
+				Expect(testutil.Output(tk, "version")).To(Equal("1.3.0"))
+				Expect(testutil.Output(tk, "increment")).To(Equal(bump.IncrementMinor))
+				Expect(testutil.Output(tk, "bumped")).To(Equal("true"))
+				Expect(testutil.Output(tk, "updated")).To(MatchJSON(`["` + path + `", "` + meta + `"]`))
+			})
+
+			it("bumps version from commits since tag prefixed with directory", func() {
+				commit("feat: add option")
+				head, err := repo.Head()
+				Expect(err).NotTo(HaveOccurred())
+				_, err = repo.CreateTag("test-name/v1.2.3", head.Hash(), nil)
+				Expect(err).NotTo(HaveOccurred())
+				commit("fix: handle empty file")
+
+				Expect(bump.BumpVersion(tk)).To(Succeed())
+
+				Expect(testutil.Output(tk, "increment")).To(Equal(bump.IncrementPatch))
+			})
+
+			it("warns and bumps version from every commit without tag", func() {
+				Expect(repo.DeleteTag("v1.2.3")).To(Succeed())
+				tk.On("Warningf", "no tag found for %s@%s, computing increment from every commit", "test-namespace/test-name", mock.Anything)
+
+				Expect(bump.BumpVersion(tk)).To(Succeed())
+
+				Expect(testutil.Output(tk, "increment")).To(Equal(bump.IncrementMajor))
+			})
+
+			it("does not bump without releasable commits", func() {
+				commit("docs: describe option")
+
+				Expect(bump.BumpVersion(tk)).To(Succeed())
+
+				Expect(read(path)).To(ContainSubstring(`version = "1.2.3" # released version`))
+				Expect(testutil.Output(tk, "version")).To(Equal("1.2.3"))
+				Expect(testutil.Output(tk, "bumped")).To(Equal("false"))
+			})
+		})
+
+		it("bumps version from increment", func() {
+			tk.On("GetInput", "increment").Return(bump.IncrementPreRelease, true)
+
+			Expect(bump.BumpVersion(tk)).To(Succeed())
+
+			Expect(read(path)).To(ContainSubstring(`version = "1.2.4-rc.1" # released version`))
+			Expect(testutil.Output(tk, "version")).To(Equal("1.2.4-rc.1"))
+		})
+
+		it("bumps inline order group references", func() {
+			tk.On("GetInput", "increment").Return(bump.IncrementPatch, true)
+			Expect(os.WriteFile(meta, []byte(`api = "0.8"
+
+[buildpack]
+id      = "test-namespace/test-meta"
+version = "1.2.3"
+
+[[order]]
+group = [{ id = "test-namespace/other", version = "1.2.3" }, { id = "test-namespace/test-name", version = "1.2.3" }]
+`), 0644)).To(Succeed())
+
+			Expect(bump.BumpVersion(tk)).To(Succeed())
+
+			Expect(read(path)).To(ContainSubstring(`version = "1.2.4" # released version`))
+			Expect(read(meta)).To(ContainSubstring(`group = [{ id = "test-namespace/other", version = "1.2.3" }, { id = "test-namespace/test-name", version = "1.2.4" }]`))
+			Expect(testutil.Output(tk, "updated")).To(MatchJSON(`["` + path + `", "` + meta + `"]`))
+		})
+
+		it("fails with invalid increment", func() {
+			tk.On("GetInput", "increment").Return("huge", true)
+
+			Expect(bump.BumpVersion(tk)).To(MatchError("::error ::invalid increment huge, must be major, minor, patch, or pre-release"))
+		})
+
+		context("next", func() {
+			next := func(v string, increment string) string {
+				n := bump.Next(*semver.MustParse(v), increment, "rc")
+				return n.String()
+			}
+
+			it("increments versions", func() {
+				Expect(next("1.2.3", bump.IncrementMajor)).To(Equal("2.0.0"))
+				Expect(next("1.2.3", bump.IncrementMinor)).To(Equal("1.3.0"))
+				Expect(next("1.2.3", bump.IncrementPatch)).To(Equal("1.2.4"))
+				Expect(next("1.2.4-rc.2", bump.IncrementPatch)).To(Equal("1.2.4"))
+			})
+
+			it("increments pre-releases", func() {
+				Expect(next("1.2.3", bump.IncrementPreRelease)).To(Equal("1.2.4-rc.1"))
+				Expect(next("1.2.4-rc.1", bump.IncrementPreRelease)).To(Equal("1.2.4-rc.2"))
+				Expect(next("1.2.4-alpha", bump.IncrementPreRelease)).To(Equal("1.2.4-rc.1"))
+			})
+		})
+	}, spec.Report(report.Terminal{}))
+}
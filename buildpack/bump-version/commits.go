@@ -0,0 +1,145 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bump
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+const (
+	IncrementMajor      = "major"
+	IncrementMinor      = "minor"
+	IncrementPatch      = "patch"
+	IncrementPreRelease = "pre-release"
+)
+
+var conventionalHeader = regexp.MustCompile(`^([a-zA-Z]+)(\([^)]*\))?(!)?: `)
+
+// Increment returns the increment implied by conventional commit messages, or "" if none of them is a feature, fix,
+// or breaking change.
+func Increment(messages []string) string {
+	increment := ""
+
+	for _, m := range messages {
+		lines := strings.Split(strings.ReplaceAll(m, "\r\n", "\n"), "\n")
+
+		g := conventionalHeader.FindStringSubmatch(lines[0])
+		if g == nil {
+			continue
+		}
+
+		if g[3] == "!" {
+			return IncrementMajor
+		}
+
+		for _, l := range lines[1:] {
+			if strings.HasPrefix(l, "BREAKING CHANGE:") || strings.HasPrefix(l, "BREAKING-CHANGE:") {
+				return IncrementMajor
+			}
+		}
+
+		switch strings.ToLower(g[1]) {
+		case "feat":
+			increment = IncrementMinor
+		case "fix", "perf":
+			if increment == "" {
+				increment = IncrementPatch
+			}
+		}
+	}
+
+	return increment
+}
+
+// Messages returns the messages of the commits reachable from HEAD of the repository containing dir but not from
+// since.  If since is empty, every commit reachable from HEAD is returned.
+func Messages(dir string, since string) ([]string, error) {
+	r, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, fmt.Errorf("unable to open git repository %s\n%w", dir, err)
+	}
+
+	head, err := r.Head()
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve HEAD\n%w", err)
+	}
+
+	excluded := map[plumbing.Hash]bool{}
+	if since != "" {
+		h, err := r.ResolveRevision(plumbing.Revision(since))
+		if err != nil {
+			return nil, fmt.Errorf("unable to resolve %s\n%w", since, err)
+		}
+
+		if err := walk(r, *h, func(c *object.Commit) error {
+			excluded[c.Hash] = true
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+	}
+
+	var messages []string
+	if err := walk(r, head.Hash(), func(c *object.Commit) error {
+		if !excluded[c.Hash] {
+			messages = append(messages, c.Message)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// Tag returns the first of names that is a tag of the repository containing dir, or "" if none of them is.
+func Tag(dir string, names ...string) (string, error) {
+	r, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return "", fmt.Errorf("unable to open git repository %s\n%w", dir, err)
+	}
+
+	for _, n := range names {
+		if _, err := r.Tag(n); err == nil {
+			return n, nil
+		} else if !errors.Is(err, git.ErrTagNotFound) {
+			return "", fmt.Errorf("unable to read tag %s\n%w", n, err)
+		}
+	}
+
+	return "", nil
+}
+
+func walk(r *git.Repository, from plumbing.Hash, fn func(c *object.Commit) error) error {
+	commits, err := r.Log(&git.LogOptions{From: from})
+	if err != nil {
+		return fmt.Errorf("unable to read history of %s\n%w", from, err)
+	}
+
+	if err := commits.ForEach(fn); err != nil {
+		return fmt.Errorf("unable to read history of %s\n%w", from, err)
+	}
+
+	return nil
+}
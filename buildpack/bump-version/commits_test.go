@@ -0,0 +1,109 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bump_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	bump "github.com/buildpacks/github-actions/buildpack/bump-version"
+)
+
+func TestCommits(t *testing.T) {
+	spec.Run(t, "commits", func(t *testing.T, context spec.G, it spec.S) {
+		var (
+			Expect = NewWithT(t).Expect
+		)
+
+		context("increment", func() {
+			it("returns nothing without conventional commits", func() {
+				Expect(bump.Increment([]string{"Update README", "chore: tidy", "docs(readme): typo"})).To(BeEmpty())
+			})
+
+			it("returns patch for fixes", func() {
+				Expect(bump.Increment([]string{"chore: tidy", "fix(detect): handle empty file"})).To(Equal(bump.IncrementPatch))
+				Expect(bump.Increment([]string{"perf: cache layer"})).To(Equal(bump.IncrementPatch))
+			})
+
+			it("returns minor for features", func() {
+				Expect(bump.Increment([]string{"feat: add option", "fix: typo"})).To(Equal(bump.IncrementMinor))
+			})
+
+			it("returns major for breaking changes", func() {
+				Expect(bump.Increment([]string{"fix: typo", "feat(build)!: remove option"})).To(Equal(bump.IncrementMajor))
+				Expect(bump.Increment([]string{"feat: replace option\n\nBREAKING CHANGE: option is removed"})).To(Equal(bump.IncrementMajor))
+			})
+		})
+
+		context("repository", func() {
+			var (
+				dir  string
+				repo *git.Repository
+			)
+
+			commit := func(message string) plumbing.Hash {
+				w, err := repo.Worktree()
+				Expect(err).NotTo(HaveOccurred())
+
+				h, err := w.Commit(message, &git.CommitOptions{
+					AllowEmptyCommits: true,
+					Author:            &object.Signature{Name: "test-name", Email: "test@example.com", When: time.Now()},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				return h
+			}
+
+			it.Before(func() {
+				var err error
+
+				dir = t.TempDir()
+				repo, err = git.PlainInit(dir, false)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = repo.CreateTag("1.2.3", commit("feat!: initial release"), nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				commit("fix: handle empty file")
+				commit("feat: add option")
+			})
+
+			it("returns messages since revision", func() {
+				Expect(bump.Messages(dir, "1.2.3")).To(Equal([]string{"feat: add option", "fix: handle empty file"}))
+			})
+
+			it("returns every message without revision", func() {
+				Expect(os.MkdirAll(filepath.Join(dir, "test-name"), 0755)).To(Succeed())
+
+				Expect(bump.Messages(filepath.Join(dir, "test-name"), "")).To(HaveLen(3))
+			})
+
+			it("falls back to later tag names", func() {
+				Expect(bump.Tag(dir, "v1.2.3", "1.2.3")).To(Equal("1.2.3"))
+				Expect(bump.Tag(dir, "v1.2.4", "1.2.4")).To(BeEmpty())
+			})
+		})
+	}, spec.Report(report.Terminal{}))
+}
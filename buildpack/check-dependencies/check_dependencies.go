@@ -0,0 +1,189 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dependencies
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/buildpacks/github-actions/buildpack/internal/descriptor"
+	"github.com/buildpacks/github-actions/internal/toolkit"
+)
+
+type dependency struct {
+	ID              string   `json:"id"`
+	Name            string   `json:"name,omitempty"`
+	Version         string   `json:"version"`
+	URI             string   `json:"uri"`
+	SHA256          string   `json:"sha256"`
+	Stacks          []string `json:"stacks,omitempty"`
+	CPEs            []string `json:"cpes,omitempty"`
+	PURL            string   `json:"purl,omitempty"`
+	DeprecationDate string   `json:"deprecation_date,omitempty"`
+}
+
+func CheckDependencies(tk toolkit.Toolkit) error {
+	c, err := parseConfig(tk)
+	if err != nil {
+		return err
+	}
+
+	d, err := descriptor.Read(c.Path, c.Kind)
+	if err != nil {
+		return toolkit.FailedError(err)
+	}
+
+	b, err := os.ReadFile(c.Path)
+	if err != nil {
+		return toolkit.FailedErrorf("unable to read %s", c.Path)
+	}
+
+	lines, err := descriptor.ParseLines(b)
+	if err != nil {
+		return toolkit.FailedErrorf("unable to parse %s\n%w", c.Path, err)
+	}
+
+	var (
+		all        = []dependency{}
+		deprecated = []string{}
+		expiring   = []string{}
+		now        = time.Now()
+		fail       bool
+	)
+
+	for i, dep := range d.Metadata.Dependencies {
+		m := toolkit.MessageContext{File: c.Path, Line: strconv.Itoa(lines.Line(fmt.Sprintf("metadata.dependencies[%d].deprecation_date", i)))}
+
+		out := dependency{
+			ID:      dep.ID,
+			Name:    dep.Name,
+			Version: dep.Version,
+			URI:     dep.URI,
+			SHA256:  dep.SHA256,
+			Stacks:  dep.Stacks,
+			CPEs:    dep.CPEs,
+			PURL:    dep.PURL,
+		}
+
+		date, ok, err := dep.Deprecation()
+		if err != nil {
+			m.Message = err.Error()
+			tk.Errorc(m)
+			fail = true
+		} else if ok {
+			out.DeprecationDate = date.Format(time.RFC3339)
+
+			if !date.After(now) {
+				m.Message = fmt.Sprintf("dependency %s@%s was deprecated on %s", dep.ID, dep.Version, date.Format("2006-01-02"))
+				deprecated = append(deprecated, fmt.Sprintf("%s@%s", dep.ID, dep.Version))
+
+				if c.FailOnDeprecated {
+					tk.Errorc(m)
+					fail = true
+				} else {
+					tk.Warningc(m)
+				}
+			} else if date.Before(now.Add(c.Window)) {
+				m.Message = fmt.Sprintf("dependency %s@%s will be deprecated on %s", dep.ID, dep.Version, date.Format("2006-01-02"))
+				expiring = append(expiring, fmt.Sprintf("%s@%s", dep.ID, dep.Version))
+
+				if c.FailOnExpiring {
+					tk.Errorc(m)
+					fail = true
+				} else {
+					tk.Warningc(m)
+				}
+			}
+		}
+
+		all = append(all, out)
+	}
+
+	for name, v := range map[string]interface{}{"dependencies": all, "deprecated": deprecated, "expiring": expiring} {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return toolkit.FailedErrorf("unable to marshal %s\n%w", name, err)
+		}
+		tk.SetOutput(name, string(b))
+	}
+
+	if fail {
+		return toolkit.FailedErrorf("%s has deprecated or expiring dependencies", c.Path)
+	}
+
+	fmt.Printf("Checked %d dependencies in %s\n", len(all), c.Path)
+	return nil
+}
+
+type config struct {
+	descriptor.Config
+
+	Window           time.Duration
+	FailOnDeprecated bool
+	FailOnExpiring   bool
+}
+
+func parseConfig(tk toolkit.Toolkit) (config, error) {
+	var c config
+
+	d, err := descriptor.ParseConfig(tk)
+	if err != nil {
+		return config{}, err
+	}
+	c.Config = d
+
+	c.Window = 30 * 24 * time.Hour
+	if s, ok := tk.GetInput("deprecation-window"); ok && s != "" {
+		w, err := parseWindow(s)
+		if err != nil {
+			return config{}, toolkit.FailedErrorf("invalid deprecation-window %s, must be a number of days such as 30d or a duration such as 720h", s)
+		}
+		c.Window = w
+	}
+
+	c.FailOnDeprecated = true
+	if s, ok := tk.GetInput("fail-on-deprecated"); ok {
+		if t, err := strconv.ParseBool(s); err == nil {
+			c.FailOnDeprecated = t
+		}
+	}
+
+	if s, ok := tk.GetInput("fail-on-expiring"); ok {
+		if t, err := strconv.ParseBool(s); err == nil {
+			c.FailOnExpiring = t
+		}
+	}
+
+	return c, nil
+}
+
+func parseWindow(s string) (time.Duration, error) {
+	if d, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(d)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid number of days %s", d)
+		}
+
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+
+	return time.ParseDuration(s)
+}
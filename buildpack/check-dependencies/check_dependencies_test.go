@@ -0,0 +1,205 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dependencies_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/stretchr/testify/mock"
+
+	dependencies "github.com/buildpacks/github-actions/buildpack/check-dependencies"
+	"github.com/buildpacks/github-actions/internal/testutil"
+	"github.com/buildpacks/github-actions/internal/toolkit"
+)
+
+func TestCheckDependencies(t *testing.T) {
+	spec.Run(t, "check-dependencies", func(t *testing.T, context spec.G, it spec.S) {
+		var (
+			Expect = NewWithT(t).Expect
+
+			path string
+			tk   = &toolkit.MockToolkit{}
+
+			past   = time.Now().AddDate(0, 0, -10).Format("2006-01-02")
+			soon   = time.Now().AddDate(0, 0, 10).Format("2006-01-02")
+			future = time.Now().AddDate(1, 0, 0).Format("2006-01-02")
+		)
+
+		write := func(dates ...string) {
+			s := `api = "0.8"
+
+[buildpack]
+id      = "test-namespace/test-name"
+version = "1.2.3"
+
+[[stacks]]
+id = "*"
+`
+			for i, d := range dates {
+				s += fmt.Sprintf(`
+[[metadata.dependencies]]
+id               = "test-dependency-%[1]d"
+version          = "1.%[1]d.0"
+uri              = "https://localhost/test-dependency-%[1]d.tgz"
+sha256           = "test-sha256-%[1]d"
+stacks           = ["*"]
+cpes             = ["cpe:2.3:a:test:test-dependency-%[1]d:1.%[1]d.0:*:*:*:*:*:*:*"]
+purl             = "pkg:generic/test-dependency-%[1]d@1.%[1]d.0"
+deprecation_date = %[2]s
+`, i+1, d)
+			}
+
+			Expect(os.WriteFile(path, []byte(s), 0644)).To(Succeed())
+		}
+
+		it.Before(func() {
+			path = filepath.Join(t.TempDir(), "buildpack.toml")
+
+			tk.On("GetInput", "kind").Return("", false)
+			tk.On("GetInput", "path").Return(path, true)
+			tk.On("Errorc", mock.Anything)
+			tk.On("Warningc", mock.Anything)
+			tk.On("SetOutput", mock.Anything, mock.Anything)
+		})
+
+		context("defaults", func() {
+			it.Before(func() {
+				tk.On("GetInput", "deprecation-window").Return("", false)
+				tk.On("GetInput", "fail-on-deprecated").Return("", false)
+				tk.On("GetInput", "fail-on-expiring").Return("", false)
+			})
+
+			it("outputs dependencies", func() {
+				write(future)
+
+				Expect(dependencies.CheckDependencies(tk)).To(Succeed())
+				tk.AssertNotCalled(t, "Errorc", mock.Anything)
+				tk.AssertNotCalled(t, "Warningc", mock.Anything)
+
+				Expect(testutil.Output(tk, "dependencies")).To(MatchJSON(fmt.Sprintf(`[{
+  "id": "test-dependency-1",
+  "version": "1.1.0",
+  "uri": "https://localhost/test-dependency-1.tgz",
+  "sha256": "test-sha256-1",
+  "stacks": ["*"],
+  "cpes": ["cpe:2.3:a:test:test-dependency-1:1.1.0:*:*:*:*:*:*:*"],
+  "purl": "pkg:generic/test-dependency-1@1.1.0",
+  "deprecation_date": "%sT00:00:00Z"
+}]`, future)))
+				Expect(testutil.Output(tk, "deprecated")).To(Equal("[]"))
+				Expect(testutil.Output(tk, "expiring")).To(Equal("[]"))
+			})
+
+			it("accepts date-time and string deprecation dates", func() {
+				write(future+"T00:00:00Z", `"`+future+`"`)
+
+				Expect(dependencies.CheckDependencies(tk)).To(Succeed())
+				tk.AssertNotCalled(t, "Errorc", mock.Anything)
+			})
+
+			it("warns about expiring dependencies", func() {
+				write(future, soon)
+
+				Expect(dependencies.CheckDependencies(tk)).To(Succeed())
+				tk.AssertCalled(t, "Warningc", toolkit.MessageContext{
+					File:    path,
+					Line:    "28",
+					Message: fmt.Sprintf("dependency test-dependency-2@1.2.0 will be deprecated on %s", soon),
+				})
+				Expect(testutil.Output(tk, "expiring")).To(Equal(`["test-dependency-2@1.2.0"]`))
+			})
+
+			it("fails on deprecated dependencies", func() {
+				write(past)
+
+				Expect(dependencies.CheckDependencies(tk)).To(MatchError("::error ::" + path + " has deprecated or expiring dependencies"))
+				tk.AssertCalled(t, "Errorc", toolkit.MessageContext{
+					File:    path,
+					Line:    "18",
+					Message: fmt.Sprintf("dependency test-dependency-1@1.1.0 was deprecated on %s", past),
+				})
+				Expect(testutil.Output(tk, "deprecated")).To(Equal(`["test-dependency-1@1.1.0"]`))
+			})
+
+			it("fails on invalid deprecation dates", func() {
+				write(`"next week"`)
+
+				Expect(dependencies.CheckDependencies(tk)).To(MatchError("::error ::" + path + " has deprecated or expiring dependencies"))
+				tk.AssertCalled(t, "Errorc", toolkit.MessageContext{
+					File:    path,
+					Line:    "18",
+					Message: "dependency test-dependency-1@1.1.0 has invalid deprecation_date next week",
+				})
+			})
+		})
+
+		context("configured", func() {
+			it.Before(func() {
+				tk.On("GetInput", "deprecation-window").Return("5d", true)
+				tk.On("GetInput", "fail-on-deprecated").Return("false", true)
+				tk.On("GetInput", "fail-on-expiring").Return("true", true)
+			})
+
+			it("warns about deprecated dependencies", func() {
+				write(past, future)
+
+				Expect(dependencies.CheckDependencies(tk)).To(Succeed())
+				tk.AssertNotCalled(t, "Errorc", mock.Anything)
+				tk.AssertCalled(t, "Warningc", toolkit.MessageContext{
+					File:    path,
+					Line:    "18",
+					Message: fmt.Sprintf("dependency test-dependency-1@1.1.0 was deprecated on %s", past),
+				})
+			})
+
+			it("uses the deprecation window", func() {
+				write(soon)
+
+				Expect(dependencies.CheckDependencies(tk)).To(Succeed())
+				Expect(testutil.Output(tk, "expiring")).To(Equal("[]"))
+			})
+		})
+
+		context("fail on expiring", func() {
+			it.Before(func() {
+				tk.On("GetInput", "deprecation-window").Return("720h", true)
+				tk.On("GetInput", "fail-on-deprecated").Return("", false)
+				tk.On("GetInput", "fail-on-expiring").Return("true", true)
+			})
+
+			it("fails on expiring dependencies", func() {
+				write(soon)
+
+				Expect(dependencies.CheckDependencies(tk)).To(MatchError("::error ::" + path + " has deprecated or expiring dependencies"))
+				Expect(testutil.Output(tk, "expiring")).To(Equal(`["test-dependency-1@1.1.0"]`))
+			})
+		})
+
+		it("fails with invalid deprecation-window", func() {
+			tk.On("GetInput", "deprecation-window").Return("a month", true)
+
+			Expect(dependencies.CheckDependencies(tk)).To(MatchError("::error ::invalid deprecation-window a month, must be a number of days such as 30d or a duration such as 720h"))
+		})
+	}, spec.Report(report.Terminal{}))
+}
@@ -17,53 +17,265 @@
 package metadata
 
 import (
+	"encoding/json"
 	"fmt"
-	"io/ioutil"
-
-	"github.com/buildpacks/libcnb"
-	"github.com/pelletier/go-toml/v2"
+	"os"
+	"strconv"
+	"strings"
 
+	"github.com/buildpacks/github-actions/buildpack/internal/descriptor"
 	"github.com/buildpacks/github-actions/internal/toolkit"
 )
 
 func ComputeMetadata(tk toolkit.Toolkit) error {
-	c := parseConfig(tk)
+	c, err := parseConfig(tk)
+	if err != nil {
+		return err
+	}
+
+	if c.Discover {
+		return discover(tk, c)
+	}
 
-	b, err := ioutil.ReadFile(c.Path)
+	d, err := descriptor.Read(c.Path, c.Kind)
 	if err != nil {
-		return toolkit.FailedErrorf("unable to read %s", c.Path)
+		return toolkit.FailedError(err)
 	}
+	info := d.Info()
 
-	var bp libcnb.Buildpack
-	if err := toml.Unmarshal(b, &bp); err != nil {
-		return toolkit.FailedErrorf("unable to unmarshal %s", c.Path)
+	if err := verifyTag(tk, c, d.Path, info.Version); err != nil {
+		return err
 	}
 
 	fmt.Printf(`Metadata:
+  Kind:     %s
   ID:       %s
   Name:     %s
   Version:  %s
   Homepage: %s
-`, bp.Info.ID, bp.Info.Name, bp.Info.Version, bp.Info.Homepage)
+`, d.Kind, info.ID, info.Name, info.Version, info.Homepage)
+
+	m := newMetadata(d)
+
+	licenses, err := json.Marshal(m.Licenses)
+	if err != nil {
+		return toolkit.FailedErrorf("unable to marshal licenses\n%w", err)
+	}
+
+	stacks, err := json.Marshal(m.Stacks)
+	if err != nil {
+		return toolkit.FailedErrorf("unable to marshal stacks\n%w", err)
+	}
+
+	targets, err := json.Marshal(m.Targets)
+	if err != nil {
+		return toolkit.FailedErrorf("unable to marshal targets\n%w", err)
+	}
+
+	order, err := json.Marshal(m.Order)
+	if err != nil {
+		return toolkit.FailedErrorf("unable to marshal order\n%w", err)
+	}
+
+	all, err := json.Marshal(m)
+	if err != nil {
+		return toolkit.FailedErrorf("unable to marshal metadata\n%w", err)
+	}
 
-	tk.SetOutput("id", bp.Info.ID)
-	tk.SetOutput("name", bp.Info.Name)
-	tk.SetOutput("version", bp.Info.Version)
-	tk.SetOutput("homepage", bp.Info.Homepage)
+	tk.SetOutput("kind", m.Kind)
+	tk.SetOutput("api", m.API)
+	tk.SetOutput("id", m.ID)
+	tk.SetOutput("name", m.Name)
+	tk.SetOutput("version", m.Version)
+	tk.SetOutput("homepage", m.Homepage)
+	tk.SetOutput("description", m.Description)
+	tk.SetOutput("keywords", strings.Join(m.Keywords, ","))
+	tk.SetOutput("licenses", string(licenses))
+	tk.SetOutput("sbom-formats", strings.Join(m.SBOMFormats, ","))
+	tk.SetOutput("clear-env", strconv.FormatBool(m.ClearEnv))
+	tk.SetOutput("stacks", string(stacks))
+	tk.SetOutput("targets", string(targets))
+	tk.SetOutput("order", string(order))
+	tk.SetOutput("json", string(all))
 
 	return nil
 }
 
+type metadata struct {
+	Path        string              `json:"path,omitempty"`
+	Kind        string              `json:"kind"`
+	API         string              `json:"api"`
+	ID          string              `json:"id"`
+	Name        string              `json:"name"`
+	Version     string              `json:"version"`
+	Homepage    string              `json:"homepage"`
+	Description string              `json:"description"`
+	Keywords    []string            `json:"keywords"`
+	Licenses    []license           `json:"licenses"`
+	SBOMFormats []string            `json:"sbom-formats"`
+	ClearEnv    bool                `json:"clear-env"`
+	Stacks      []stack             `json:"stacks"`
+	Targets     []descriptor.Target `json:"targets"`
+	Order       []group             `json:"order"`
+}
+
+type license struct {
+	Type string `json:"type,omitempty"`
+	URI  string `json:"uri,omitempty"`
+}
+
+type stack struct {
+	ID     string   `json:"id"`
+	Mixins []string `json:"mixins,omitempty"`
+}
+
+type group struct {
+	Group []groupEntry `json:"group"`
+}
+
+type groupEntry struct {
+	ID       string `json:"id"`
+	Version  string `json:"version"`
+	Optional bool   `json:"optional,omitempty"`
+}
+
+func newMetadata(d descriptor.Descriptor) metadata {
+	info := d.Info()
+
+	m := metadata{
+		Kind:        d.Kind,
+		API:         d.API,
+		ID:          info.ID,
+		Name:        info.Name,
+		Version:     info.Version,
+		Homepage:    info.Homepage,
+		Description: info.Description,
+		Keywords:    []string{},
+		Licenses:    []license{},
+		SBOMFormats: []string{},
+		ClearEnv:    info.ClearEnvironment,
+		Stacks:      []stack{},
+		Targets:     []descriptor.Target{},
+		Order:       []group{},
+	}
+
+	m.Keywords = append(m.Keywords, info.Keywords...)
+	m.SBOMFormats = append(m.SBOMFormats, info.SBOMFormats...)
+	m.Targets = append(m.Targets, d.Targets...)
+
+	for _, l := range info.Licenses {
+		m.Licenses = append(m.Licenses, license{Type: l.Type, URI: l.URI})
+	}
+
+	for _, s := range d.Stacks {
+		m.Stacks = append(m.Stacks, stack{ID: s.ID, Mixins: s.Mixins})
+	}
+
+	for _, o := range d.Order {
+		g := group{Group: []groupEntry{}}
+		for _, e := range o.Groups {
+			g.Group = append(g.Group, groupEntry{ID: e.ID, Version: e.Version, Optional: e.Optional})
+		}
+		m.Order = append(m.Order, g)
+	}
+
+	return m
+}
+
+// verifyTag fails if the version of a descriptor does not match the version of a tag, which may be a ref such as
+// refs/tags/v1.2.3 and may be prefixed with a path such as test-name/v1.2.3.  If no tag is set, GITHUB_REF is used and
+// refs that are not tags are skipped.
+func verifyTag(tk toolkit.Toolkit, c config, path string, version string) error {
+	if !c.VerifyTag {
+		return nil
+	}
+
+	tag, ok := resolveTag(tk, c)
+	if !ok {
+		return nil
+	}
+
+	_, v := parseTag(tag)
+	return compareVersion(path, version, tag, v)
+}
+
+// resolveTag returns the tag input or, if it is not set, GITHUB_REF if it is a tag ref.
+func resolveTag(tk toolkit.Toolkit, c config) (string, bool) {
+	if c.Tag != "" {
+		return c.Tag, true
+	}
+
+	ref, ok := os.LookupEnv("GITHUB_REF")
+	if !ok || !strings.HasPrefix(ref, "refs/tags/") {
+		tk.Warningf("skipping tag verification, %s is not a tag", ref)
+		return "", false
+	}
+
+	return ref, true
+}
+
+// parseTag returns the path prefix, if any, and the version of a tag such as refs/tags/test-name/v1.2.3.
+func parseTag(tag string) (string, string) {
+	var prefix string
+
+	v := strings.TrimPrefix(tag, "refs/tags/")
+	if i := strings.LastIndex(v, "/"); i >= 0 {
+		prefix, v = v[:i], v[i+1:]
+	}
+
+	return prefix, strings.TrimPrefix(v, "v")
+}
+
+func compareVersion(path string, version string, tag string, v string) error {
+	if v != version {
+		return toolkit.FailedErrorf("%s has version %s but tag %s is for version %s", path, version, tag, v)
+	}
+
+	fmt.Printf("Verified version %s of %s matches tag %s\n", version, path, tag)
+	return nil
+}
+
 type config struct {
-	Path string
+	descriptor.Config
+
+	Discover  bool
+	Directory string
+	VerifyTag bool
+	Tag       string
 }
 
-func parseConfig(tk toolkit.Toolkit) config {
-	c := config{Path: "buildpack.toml"}
+func parseConfig(tk toolkit.Toolkit) (config, error) {
+	var c config
+
+	d, err := descriptor.ParseConfig(tk)
+	if err != nil {
+		return config{}, err
+	}
+	c.Config = d
+
+	if s, ok := tk.GetInput("discover"); ok {
+		if t, err := strconv.ParseBool(s); err == nil {
+			c.Discover = t
+		}
+	}
+
+	if s, ok := tk.GetInput("tag"); ok && s != "" {
+		c.Tag = s
+		c.VerifyTag = true
+	}
+
+	if s, ok := tk.GetInput("verify-tag"); ok {
+		if t, err := strconv.ParseBool(s); err == nil {
+			c.VerifyTag = c.VerifyTag || t
+		}
+	}
 
-	if s, ok := tk.GetInput("path"); ok {
-		c.Path = s
+	if c.Discover {
+		c.Directory = "."
+		if s, ok := tk.GetInput("directory"); ok {
+			c.Directory = s
+		}
 	}
 
-	return c
+	return c, nil
 }
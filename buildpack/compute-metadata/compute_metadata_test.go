@@ -17,14 +17,19 @@
 package metadata_test
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
 	"path/filepath"
 	"testing"
 
 	. "github.com/onsi/gomega"
 	"github.com/sclevine/spec"
 	"github.com/sclevine/spec/report"
+	"github.com/stretchr/testify/mock"
 
 	"github.com/buildpacks/github-actions/buildpack/compute-metadata"
+	"github.com/buildpacks/github-actions/internal/testutil"
 	"github.com/buildpacks/github-actions/internal/toolkit"
 )
 
@@ -36,17 +41,319 @@ func TestComputeMetadata(t *testing.T) {
 			tk = &toolkit.MockToolkit{}
 		)
 
-		it.Before(func() {
-			tk.On("GetInput", "path").Return(filepath.Join("testdata", "buildpack.toml"), true)
+		context("buildpack", func() {
+			it.Before(func() {
+				tk.On("GetInput", "kind").Return("", false)
+				tk.On("GetInput", "discover").Return("", false)
+				tk.On("GetInput", "path").Return(filepath.Join("testdata", "buildpack.toml"), true)
+				tk.On("GetInput", "tag").Return("", false)
+				tk.On("GetInput", "verify-tag").Return("", false)
+			})
+
+			it("computes metadata", func() {
+				tk.On("SetOutput", "kind", "buildpack")
+				tk.On("SetOutput", "id", "test-id")
+				tk.On("SetOutput", "name", "test-name")
+				tk.On("SetOutput", "version", "test-version")
+				tk.On("SetOutput", "homepage", "test-homepage")
+				tk.On("SetOutput", "api", "")
+				tk.On("SetOutput", "description", "")
+				tk.On("SetOutput", "keywords", "")
+				tk.On("SetOutput", "licenses", "[]")
+				tk.On("SetOutput", "sbom-formats", "")
+				tk.On("SetOutput", "clear-env", "false")
+				tk.On("SetOutput", "stacks", "[]")
+				tk.On("SetOutput", "targets", "[]")
+				tk.On("SetOutput", "order", "[]")
+				tk.On("SetOutput", "json", mock.Anything)
+
+				Expect(metadata.ComputeMetadata(tk)).To(Succeed())
+			})
+		})
+
+		context("extension", func() {
+			it.Before(func() {
+				tk.On("GetInput", "kind").Return("", false)
+				tk.On("GetInput", "discover").Return("", false)
+				tk.On("GetInput", "path").Return(filepath.Join("testdata", "extension.toml"), true)
+				tk.On("GetInput", "tag").Return("", false)
+				tk.On("GetInput", "verify-tag").Return("", false)
+			})
+
+			it("computes metadata", func() {
+				tk.On("SetOutput", "kind", "extension")
+				tk.On("SetOutput", "id", "test-extension-id")
+				tk.On("SetOutput", "name", "test-extension-name")
+				tk.On("SetOutput", "version", "test-extension-version")
+				tk.On("SetOutput", "homepage", "test-extension-homepage")
+				tk.On("SetOutput", "api", "0.10")
+				tk.On("SetOutput", "description", "")
+				tk.On("SetOutput", "keywords", "")
+				tk.On("SetOutput", "licenses", "[]")
+				tk.On("SetOutput", "sbom-formats", "")
+				tk.On("SetOutput", "clear-env", "false")
+				tk.On("SetOutput", "stacks", "[]")
+				tk.On("SetOutput", "targets", "[]")
+				tk.On("SetOutput", "order", "[]")
+				tk.On("SetOutput", "json", mock.Anything)
+
+				Expect(metadata.ComputeMetadata(tk)).To(Succeed())
+			})
+		})
+
+		context("complete buildpack", func() {
+			it.Before(func() {
+				tk.On("GetInput", "kind").Return("", false)
+				tk.On("GetInput", "discover").Return("", false)
+				tk.On("GetInput", "path").Return(filepath.Join("testdata", "complete.toml"), true)
+				tk.On("GetInput", "tag").Return("", false)
+				tk.On("GetInput", "verify-tag").Return("", false)
+				tk.On("SetOutput", mock.Anything, mock.Anything)
+			})
+
+			it("computes metadata", func() {
+				Expect(metadata.ComputeMetadata(tk)).To(Succeed())
+
+				tk.AssertCalled(t, "SetOutput", "api", "0.10")
+				tk.AssertCalled(t, "SetOutput", "description", "test-description")
+				tk.AssertCalled(t, "SetOutput", "keywords", "test-keyword-1,test-keyword-2")
+				tk.AssertCalled(t, "SetOutput", "licenses", `[{"type":"Apache-2.0","uri":"https://www.apache.org/licenses/LICENSE-2.0"}]`)
+				tk.AssertCalled(t, "SetOutput", "sbom-formats", "application/vnd.cyclonedx+json,application/spdx+json")
+				tk.AssertCalled(t, "SetOutput", "clear-env", "true")
+				tk.AssertCalled(t, "SetOutput", "stacks", `[{"id":"test-stack","mixins":["test-mixin"]}]`)
+				tk.AssertCalled(t, "SetOutput", "targets", `[{"os":"linux","arch":"arm64","variant":"v8","distros":[{"name":"ubuntu","version":"22.04"}]}]`)
+				tk.AssertCalled(t, "SetOutput", "order",
+					`[{"group":[{"id":"test-group-id-1","version":"test-group-version-1"},{"id":"test-group-id-2","version":"test-group-version-2","optional":true}]}]`)
+				tk.AssertCalled(t, "SetOutput", "json", `{"kind":"buildpack","api":"0.10","id":"test-id","name":"test-name","version":"test-version",`+
+					`"homepage":"test-homepage","description":"test-description","keywords":["test-keyword-1","test-keyword-2"],`+
+					`"licenses":[{"type":"Apache-2.0","uri":"https://www.apache.org/licenses/LICENSE-2.0"}],`+
+					`"sbom-formats":["application/vnd.cyclonedx+json","application/spdx+json"],"clear-env":true,`+
+					`"stacks":[{"id":"test-stack","mixins":["test-mixin"]}],`+
+					`"targets":[{"os":"linux","arch":"arm64","variant":"v8","distros":[{"name":"ubuntu","version":"22.04"}]}],`+
+					`"order":[{"group":[{"id":"test-group-id-1","version":"test-group-version-1"},{"id":"test-group-id-2","version":"test-group-version-2","optional":true}]}]}`)
+			})
+		})
+
+		context("discover monorepo", func() {
+			// the descriptor package's discover fixture, whose hidden directory is skipped
+			dir := filepath.Join("..", "internal", "descriptor", "testdata", "discover")
+
+			it.Before(func() {
+				tk.On("GetInput", "kind").Return("", false)
+				tk.On("GetInput", "discover").Return("true", true)
+				tk.On("GetInput", "path").Return("", false)
+				tk.On("GetInput", "directory").Return(dir, true)
+				tk.On("GetInput", "tag").Return("", false)
+				tk.On("GetInput", "verify-tag").Return("", false)
+				tk.On("SetOutput", mock.Anything, mock.Anything)
+			})
+
+			it("computes metadata and build order", func() {
+				Expect(metadata.ComputeMetadata(tk)).To(Succeed())
+
+				var buildpacks []map[string]interface{}
+				Expect(json.Unmarshal([]byte(testutil.Output(tk, "buildpacks")), &buildpacks)).To(Succeed())
+				Expect(buildpacks).To(HaveLen(4))
+				Expect(buildpacks[0]).To(HaveKeyWithValue("id", "test/a"))
+				Expect(buildpacks[0]).To(HaveKeyWithValue("path", filepath.Join(dir, "a", "buildpack.toml")))
+				Expect(buildpacks[2]).To(HaveKeyWithValue("kind", "extension"))
+
+				Expect(testutil.Output(tk, "build-order")).To(Equal(`["test/a","test/b","test/ext","test/meta"]`))
+			})
+		})
+
+		context("discover", func() {
+			var dir string
+
+			write := func(path string, content string) {
+				Expect(os.MkdirAll(filepath.Join(dir, filepath.Dir(path)), 0755)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(dir, path), []byte(content), 0644)).To(Succeed())
+			}
+
+			it.Before(func() {
+				dir = t.TempDir()
+
+				tk.On("GetInput", "kind").Return("", false)
+				tk.On("GetInput", "discover").Return("true", true)
+				tk.On("GetInput", "path").Return("", false)
+				tk.On("GetInput", "directory").Return(dir, true)
+				tk.On("GetInput", "tag").Return("", false)
+				tk.On("GetInput", "verify-tag").Return("", false)
+				tk.On("SetOutput", mock.Anything, mock.Anything)
+			})
+
+			it("orders meta-buildpacks after their groups", func() {
+				write("a/buildpack.toml", "[buildpack]\nid = \"test/a\"\n[[order]]\n[[order.group]]\nid = \"test/z\"\n")
+				write("z/buildpack.toml", "[buildpack]\nid = \"test/z\"\n[[order]]\n[[order.group]]\nid = \"test/y\"\n[[order.group]]\nid = \"external/x\"\n")
+				write("y/buildpack.toml", "[buildpack]\nid = \"test/y\"\n")
+
+				Expect(metadata.ComputeMetadata(tk)).To(Succeed())
+				Expect(testutil.Output(tk, "build-order")).To(Equal(`["test/y","test/z","test/a"]`))
+			})
+
+			it("fails on duplicate ids", func() {
+				write("a/buildpack.toml", "[buildpack]\nid = \"test/a\"\n")
+				write("b/buildpack.toml", "[buildpack]\nid = \"test/a\"\n")
+
+				Expect(metadata.ComputeMetadata(tk)).To(MatchError(fmt.Sprintf("::error ::duplicate id test/a in %s and %s",
+					filepath.Join(dir, "a", "buildpack.toml"), filepath.Join(dir, "b", "buildpack.toml"))))
+			})
+
+			it("fails on cycles", func() {
+				write("a/buildpack.toml", "[buildpack]\nid = \"test/a\"\n[[order]]\n[[order.group]]\nid = \"test/b\"\n")
+				write("b/buildpack.toml", "[buildpack]\nid = \"test/b\"\n[[order]]\n[[order.group]]\nid = \"test/a\"\n")
+				write("c/buildpack.toml", "[buildpack]\nid = \"test/c\"\n")
+
+				Expect(metadata.ComputeMetadata(tk)).To(MatchError("::error ::order groups of test/a, test/b form a cycle"))
+			})
+		})
+
+		context("discover and verify tag", func() {
+			var dir string
+
+			it.Before(func() {
+				dir = t.TempDir()
+
+				for _, p := range []string{"a", "b"} {
+					Expect(os.MkdirAll(filepath.Join(dir, p), 0755)).To(Succeed())
+				}
+				Expect(os.WriteFile(filepath.Join(dir, "a", "buildpack.toml"), []byte("[buildpack]\nid = \"test/a\"\nversion = \"1.4.0\"\n"), 0644)).To(Succeed())
+				Expect(os.WriteFile(filepath.Join(dir, "b", "buildpack.toml"), []byte("[buildpack]\nid = \"test/b\"\nversion = \"2.0.0\"\n"), 0644)).To(Succeed())
+
+				tk.On("GetInput", "kind").Return("", false)
+				tk.On("GetInput", "discover").Return("true", true)
+				tk.On("GetInput", "path").Return("", false)
+				tk.On("GetInput", "directory").Return(dir, true)
+				tk.On("GetInput", "verify-tag").Return("", false)
+				tk.On("SetOutput", mock.Anything, mock.Anything)
+			})
+
+			it("verifies descriptor with id of tag prefix", func() {
+				tk.On("GetInput", "tag").Return("test/a/v1.4.0", true)
+
+				Expect(metadata.ComputeMetadata(tk)).To(Succeed())
+			})
+
+			it("verifies descriptor with directory of tag prefix", func() {
+				tk.On("GetInput", "tag").Return("refs/tags/b/v2.0.0", true)
+
+				Expect(metadata.ComputeMetadata(tk)).To(Succeed())
+			})
+
+			it("fails on mismatch", func() {
+				tk.On("GetInput", "tag").Return("test/b/v1.4.0", true)
+
+				Expect(metadata.ComputeMetadata(tk)).
+					To(MatchError(fmt.Sprintf("::error ::%s has version 2.0.0 but tag test/b/v1.4.0 is for version 1.4.0", filepath.Join(dir, "b", "buildpack.toml"))))
+			})
+
+			it("fails without tag prefix", func() {
+				tk.On("GetInput", "tag").Return("v1.4.0", true)
+
+				Expect(metadata.ComputeMetadata(tk)).
+					To(MatchError("::error ::tag v1.4.0 must be prefixed with the id or directory of a descriptor to be verified with discover"))
+			})
+
+			it("fails if no descriptor matches tag prefix", func() {
+				tk.On("GetInput", "tag").Return("test/c/v1.4.0", true)
+
+				Expect(metadata.ComputeMetadata(tk)).
+					To(MatchError(fmt.Sprintf("::error ::no descriptor in %s has id or directory test/c of tag test/c/v1.4.0", dir)))
+			})
+		})
+
+		context("verify tag", func() {
+			var path string
+
+			it.Before(func() {
+				path = filepath.Join(t.TempDir(), "buildpack.toml")
+				Expect(os.WriteFile(path, []byte("[buildpack]\nid = \"test-id\"\nversion = \"1.4.0\"\n"), 0644)).To(Succeed())
+
+				tk.On("GetInput", "kind").Return("", false)
+				tk.On("GetInput", "discover").Return("", false)
+				tk.On("GetInput", "path").Return(path, true)
+				tk.On("SetOutput", mock.Anything, mock.Anything)
+			})
+
+			context("tag", func() {
+				it.Before(func() {
+					tk.On("GetInput", "verify-tag").Return("", false)
+				})
+
+				it("accepts tag with leading v", func() {
+					tk.On("GetInput", "tag").Return("v1.4.0", true)
+
+					Expect(metadata.ComputeMetadata(tk)).To(Succeed())
+				})
+
+				it("accepts tag ref with path", func() {
+					tk.On("GetInput", "tag").Return("refs/tags/test-id/1.4.0", true)
+
+					Expect(metadata.ComputeMetadata(tk)).To(Succeed())
+				})
+
+				it("fails on mismatch", func() {
+					tk.On("GetInput", "tag").Return("v1.3.9", true)
+
+					Expect(metadata.ComputeMetadata(tk)).
+						To(MatchError(fmt.Sprintf("::error ::%s has version 1.4.0 but tag v1.3.9 is for version 1.3.9", path)))
+				})
+			})
+
+			context("GITHUB_REF", func() {
+				it.Before(func() {
+					tk.On("GetInput", "tag").Return("", false)
+					tk.On("GetInput", "verify-tag").Return("true", true)
+				})
+
+				it("accepts matching tag", func() {
+					t.Setenv("GITHUB_REF", "refs/tags/v1.4.0")
+
+					Expect(metadata.ComputeMetadata(tk)).To(Succeed())
+				})
+
+				it("fails on mismatch", func() {
+					t.Setenv("GITHUB_REF", "refs/tags/v1.3.9")
+
+					Expect(metadata.ComputeMetadata(tk)).
+						To(MatchError(fmt.Sprintf("::error ::%s has version 1.4.0 but tag refs/tags/v1.3.9 is for version 1.3.9", path)))
+				})
+
+				it("skips refs that are not tags", func() {
+					t.Setenv("GITHUB_REF", "refs/heads/main")
+					tk.On("Warningf", "skipping tag verification, %s is not a tag", "refs/heads/main")
+
+					Expect(metadata.ComputeMetadata(tk)).To(Succeed())
+					tk.AssertCalled(t, "Warningf", "skipping tag verification, %s is not a tag", "refs/heads/main")
+				})
+			})
+		})
+
+		context("kind is set", func() {
+			it.Before(func() {
+				tk.On("GetInput", "kind").Return("buildpack", true)
+				tk.On("GetInput", "discover").Return("", false)
+				tk.On("GetInput", "path").Return(filepath.Join("testdata", "extension.toml"), true)
+				tk.On("GetInput", "tag").Return("", false)
+				tk.On("GetInput", "verify-tag").Return("", false)
+			})
+
+			it("fails if descriptor is of another kind", func() {
+				Expect(metadata.ComputeMetadata(tk)).
+					To(MatchError(fmt.Sprintf("::error ::%s has kind extension, expected buildpack", filepath.Join("testdata", "extension.toml"))))
+			})
 		})
 
-		it("computes metadata", func() {
-			tk.On("SetOutput", "id", "test-id")
-			tk.On("SetOutput", "name", "test-name")
-			tk.On("SetOutput", "version", "test-version")
-			tk.On("SetOutput", "homepage", "test-homepage")
+		context("kind is invalid", func() {
+			it.Before(func() {
+				tk.On("GetInput", "kind").Return("builder", true)
+				tk.On("GetInput", "discover").Return("", false)
+			})
 
-			Expect(metadata.ComputeMetadata(tk)).To(Succeed())
+			it("fails", func() {
+				Expect(metadata.ComputeMetadata(tk)).
+					To(MatchError("::error ::invalid kind builder, must be buildpack or extension"))
+			})
 		})
 	}, spec.Report(report.Terminal{}))
 }
@@ -0,0 +1,174 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package metadata
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/buildpacks/github-actions/buildpack/internal/descriptor"
+	"github.com/buildpacks/github-actions/internal/toolkit"
+)
+
+func discover(tk toolkit.Toolkit, c config) error {
+	descriptors, err := descriptor.Discover(c.Directory, c.Kind)
+	if err != nil {
+		return toolkit.FailedError(err)
+	}
+
+	var (
+		all   = []metadata{}
+		paths = map[string]string{}
+	)
+
+	for _, d := range descriptors {
+		m := newMetadata(d)
+		m.Path = d.Path
+
+		if p, ok := paths[m.ID]; ok {
+			return toolkit.FailedErrorf("duplicate id %s in %s and %s", m.ID, p, m.Path)
+		}
+		paths[m.ID] = m.Path
+
+		fmt.Printf("Discovered %s %s@%s in %s\n", m.Kind, m.ID, m.Version, m.Path)
+		all = append(all, m)
+	}
+
+	if err := verifyDiscoveredTag(tk, c, all); err != nil {
+		return err
+	}
+
+	order, err := buildOrder(all)
+	if err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(all)
+	if err != nil {
+		return toolkit.FailedErrorf("unable to marshal metadata\n%w", err)
+	}
+	tk.SetOutput("buildpacks", string(b))
+
+	b, err = json.Marshal(order)
+	if err != nil {
+		return toolkit.FailedErrorf("unable to marshal build order\n%w", err)
+	}
+	tk.SetOutput("build-order", string(b))
+
+	return nil
+}
+
+// verifyDiscoveredTag verifies the version of the descriptor whose id or directory, relative to the discovered
+// directory, is the path prefix of the tag, such as test/a/v1.2.3.
+func verifyDiscoveredTag(tk toolkit.Toolkit, c config, all []metadata) error {
+	if !c.VerifyTag {
+		return nil
+	}
+
+	tag, ok := resolveTag(tk, c)
+	if !ok {
+		return nil
+	}
+
+	prefix, v := parseTag(tag)
+	if prefix == "" {
+		return toolkit.FailedErrorf("tag %s must be prefixed with the id or directory of a descriptor to be verified with discover", tag)
+	}
+
+	for _, m := range all {
+		dir, err := filepath.Rel(c.Directory, filepath.Dir(m.Path))
+		if err != nil {
+			return toolkit.FailedErrorf("unable to determine directory of %s\n%w", m.Path, err)
+		}
+
+		if m.ID == prefix || filepath.ToSlash(dir) == prefix {
+			return compareVersion(m.Path, m.Version, tag, v)
+		}
+	}
+
+	return toolkit.FailedErrorf("no descriptor in %s has id or directory %s of tag %s", c.Directory, prefix, tag)
+}
+
+// buildOrder returns the ids of all buildpacks such that each meta-buildpack comes after the discovered buildpacks in
+// its order groups.  Buildpacks that are not discovered are ignored and ties are broken by id.
+func buildOrder(all []metadata) ([]string, error) {
+	var (
+		dependents = map[string][]string{}
+		ids        = map[string]bool{}
+		pending    = map[string]int{}
+		order      = []string{}
+		ready      []string
+	)
+
+	for _, m := range all {
+		ids[m.ID] = true
+	}
+
+	for _, m := range all {
+		pending[m.ID] = 0
+
+		seen := map[string]bool{}
+		for _, g := range m.Order {
+			for _, e := range g.Group {
+				if !ids[e.ID] || seen[e.ID] {
+					continue
+				}
+				seen[e.ID] = true
+
+				dependents[e.ID] = append(dependents[e.ID], m.ID)
+				pending[m.ID]++
+			}
+		}
+	}
+
+	for id, n := range pending {
+		if n == 0 {
+			ready = append(ready, id)
+		}
+	}
+
+	for len(ready) > 0 {
+		sort.Strings(ready)
+		id := ready[0]
+		ready = ready[1:]
+		order = append(order, id)
+
+		for _, d := range dependents[id] {
+			pending[d]--
+			if pending[d] == 0 {
+				ready = append(ready, d)
+			}
+		}
+	}
+
+	if len(order) < len(all) {
+		var cycle []string
+		for id, n := range pending {
+			if n > 0 {
+				cycle = append(cycle, id)
+			}
+		}
+		sort.Strings(cycle)
+
+		return nil, toolkit.FailedErrorf("order groups of %s form a cycle", strings.Join(cycle, ", "))
+	}
+
+	return order, nil
+}
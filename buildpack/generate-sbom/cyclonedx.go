@@ -0,0 +1,184 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sbom
+
+import (
+	"fmt"
+
+	"github.com/buildpacks/libcnb"
+
+	"github.com/buildpacks/github-actions/buildpack/internal/descriptor"
+)
+
+// CycloneDX is a CycloneDX 1.5 JSON document.
+type CycloneDX struct {
+	BOMFormat    string                `json:"bomFormat"`
+	SpecVersion  string                `json:"specVersion"`
+	SerialNumber string                `json:"serialNumber"`
+	Version      int                   `json:"version"`
+	Metadata     CycloneDXMetadata     `json:"metadata"`
+	Components   []CycloneDXComponent  `json:"components"`
+	Dependencies []CycloneDXDependency `json:"dependencies"`
+}
+
+type CycloneDXMetadata struct {
+	Timestamp string             `json:"timestamp,omitempty"`
+	Component CycloneDXComponent `json:"component"`
+}
+
+type CycloneDXComponent struct {
+	Type               string                       `json:"type"`
+	BOMRef             string                       `json:"bom-ref"`
+	Name               string                       `json:"name"`
+	Version            string                       `json:"version,omitempty"`
+	Description        string                       `json:"description,omitempty"`
+	Hashes             []CycloneDXHash              `json:"hashes,omitempty"`
+	Licenses           []CycloneDXLicenseChoice     `json:"licenses,omitempty"`
+	CPE                string                       `json:"cpe,omitempty"`
+	PURL               string                       `json:"purl,omitempty"`
+	ExternalReferences []CycloneDXExternalReference `json:"externalReferences,omitempty"`
+	Properties         []CycloneDXProperty          `json:"properties,omitempty"`
+}
+
+type CycloneDXHash struct {
+	Algorithm string `json:"alg"`
+	Content   string `json:"content"`
+}
+
+type CycloneDXLicenseChoice struct {
+	License CycloneDXLicense `json:"license"`
+}
+
+type CycloneDXLicense struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+	URL  string `json:"url,omitempty"`
+}
+
+type CycloneDXExternalReference struct {
+	Type string `json:"type"`
+	URL  string `json:"url"`
+}
+
+type CycloneDXProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type CycloneDXDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+// NewCycloneDX returns a CycloneDX document describing a buildpack as the application and its dependencies as
+// libraries it depends on.
+func NewCycloneDX(info libcnb.BuildpackInfo, dependencies []descriptor.Dependency, serial string, timestamp string) CycloneDX {
+	root := CycloneDXComponent{
+		Type:        "application",
+		BOMRef:      fmt.Sprintf("%s@%s", info.ID, info.Version),
+		Name:        info.ID,
+		Version:     info.Version,
+		Description: info.Description,
+	}
+
+	for _, l := range info.Licenses {
+		root.Licenses = append(root.Licenses, cycloneDXLicense(l.Type, l.URI))
+	}
+
+	if info.Homepage != "" {
+		root.ExternalReferences = append(root.ExternalReferences, CycloneDXExternalReference{Type: "website", URL: info.Homepage})
+	}
+
+	c := CycloneDX{
+		BOMFormat:    "CycloneDX",
+		SpecVersion:  "1.5",
+		SerialNumber: fmt.Sprintf("urn:uuid:%s", serial),
+		Version:      1,
+		Metadata:     CycloneDXMetadata{Timestamp: timestamp, Component: root},
+		Components:   []CycloneDXComponent{},
+		Dependencies: []CycloneDXDependency{{Ref: root.BOMRef, DependsOn: []string{}}},
+	}
+
+	// dependencies that differ only in their uri would share a reference, so later ones are numbered
+	refs := map[string]bool{root.BOMRef: true}
+
+	for _, d := range dependencies {
+		ref := fmt.Sprintf("%s@%s", d.ID, d.Version)
+		if d.SHA256 != "" {
+			ref = fmt.Sprintf("%s:%s", ref, d.SHA256)
+		}
+
+		component := CycloneDXComponent{
+			Type:    "library",
+			BOMRef:  ref,
+			Name:    d.ID,
+			Version: d.Version,
+			PURL:    d.PURL,
+		}
+
+		for i := 2; refs[component.BOMRef]; i++ {
+			component.BOMRef = fmt.Sprintf("%s#%d", ref, i)
+		}
+		refs[component.BOMRef] = true
+
+		if d.SHA256 != "" {
+			component.Hashes = []CycloneDXHash{{Algorithm: "SHA-256", Content: d.SHA256}}
+		}
+
+		if d.Name != "" {
+			component.Description = d.Name
+		}
+
+		for _, t := range d.LicenseTypes() {
+			component.Licenses = append(component.Licenses, cycloneDXLicense(t, ""))
+		}
+
+		if len(d.CPEs) > 0 {
+			component.CPE = d.CPEs[0]
+
+			for _, cpe := range d.CPEs[1:] {
+				component.Properties = append(component.Properties, CycloneDXProperty{Name: "io.buildpacks:cpe", Value: cpe})
+			}
+		}
+
+		for _, s := range d.Stacks {
+			component.Properties = append(component.Properties, CycloneDXProperty{Name: "io.buildpacks:stack", Value: s})
+		}
+
+		if d.URI != "" {
+			component.ExternalReferences = []CycloneDXExternalReference{{Type: "distribution", URL: d.URI}}
+		}
+
+		c.Components = append(c.Components, component)
+		c.Dependencies[0].DependsOn = append(c.Dependencies[0].DependsOn, component.BOMRef)
+	}
+
+	return c
+}
+
+func cycloneDXLicense(t string, uri string) CycloneDXLicenseChoice {
+	if descriptor.SPDXLicense(t) {
+		return CycloneDXLicenseChoice{License: CycloneDXLicense{ID: t, URL: uri}}
+	}
+
+	name := t
+	if name == "" {
+		name = uri
+	}
+
+	return CycloneDXLicenseChoice{License: CycloneDXLicense{Name: name, URL: uri}}
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sbom_test
+
+import (
+	"testing"
+
+	"github.com/buildpacks/libcnb"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	sbom "github.com/buildpacks/github-actions/buildpack/generate-sbom"
+	"github.com/buildpacks/github-actions/buildpack/internal/descriptor"
+)
+
+func TestCycloneDX(t *testing.T) {
+	spec.Run(t, "cyclonedx", func(t *testing.T, context spec.G, it spec.S) {
+		var (
+			Expect = NewWithT(t).Expect
+		)
+
+		it("numbers references of dependencies that differ only in uri", func() {
+			c := sbom.NewCycloneDX(libcnb.BuildpackInfo{ID: "test-id", Version: "1.2.3"}, []descriptor.Dependency{
+				{ID: "test-dependency", Version: "1.0.0", SHA256: "a1a2", URI: "https://example.com/amd64.tgz"},
+				{ID: "test-dependency", Version: "1.0.0", SHA256: "a1a2", URI: "https://example.com/arm64.tgz"},
+				{ID: "test-dependency", Version: "1.0.0", URI: "https://example.com/other.tgz"},
+			}, "test-serial", "")
+
+			Expect(c.Components).To(HaveLen(3))
+			Expect(c.Components[0].BOMRef).To(Equal("test-dependency@1.0.0:a1a2"))
+			Expect(c.Components[1].BOMRef).To(Equal("test-dependency@1.0.0:a1a2#2"))
+			Expect(c.Components[2].BOMRef).To(Equal("test-dependency@1.0.0"))
+			Expect(c.Dependencies[0].DependsOn).To(Equal([]string{"test-dependency@1.0.0:a1a2", "test-dependency@1.0.0:a1a2#2", "test-dependency@1.0.0"}))
+		})
+	}, spec.Report(report.Terminal{}))
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sbom
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/buildpacks/github-actions/buildpack/internal/descriptor"
+	"github.com/buildpacks/github-actions/internal/toolkit"
+)
+
+func GenerateSBOM(tk toolkit.Toolkit) error {
+	c, err := parseConfig(tk)
+	if err != nil {
+		return err
+	}
+
+	d, err := descriptor.Read(c.Path, c.Kind)
+	if err != nil {
+		return toolkit.FailedError(err)
+	}
+
+	var (
+		info         = d.Info()
+		dependencies = sorted(d.Metadata.Dependencies)
+	)
+
+	b, err := json.Marshal([]interface{}{info, dependencies})
+	if err != nil {
+		return toolkit.FailedErrorf("unable to marshal %s\n%w", c.Path, err)
+	}
+	serial := uuid(b)
+
+	if err := write(c.CycloneDXPath, NewCycloneDX(info, dependencies, serial, c.Timestamp)); err != nil {
+		return err
+	}
+
+	if err := write(c.SPDXPath, NewSPDX(info, dependencies, serial, c.Timestamp)); err != nil {
+		return err
+	}
+
+	fmt.Printf("Wrote SBOMs of %s@%s with %d dependencies to %s and %s\n", info.ID, info.Version, len(dependencies), c.CycloneDXPath, c.SPDXPath)
+
+	tk.SetOutput("cyclonedx-path", c.CycloneDXPath)
+	tk.SetOutput("spdx-path", c.SPDXPath)
+
+	return nil
+}
+
+// sorted returns dependencies ordered by id, version, sha256, and uri without exact duplicates so that SBOMs do not
+// depend on the order of buildpack.toml.
+func sorted(dependencies []descriptor.Dependency) []descriptor.Dependency {
+	var s []descriptor.Dependency
+
+	key := func(d descriptor.Dependency) string {
+		return strings.Join([]string{d.ID, d.Version, d.SHA256, d.URI}, "\x00")
+	}
+
+	seen := map[string]bool{}
+	for _, d := range dependencies {
+		if k := key(d); !seen[k] {
+			seen[k] = true
+			s = append(s, d)
+		}
+	}
+
+	sort.SliceStable(s, func(i, j int) bool {
+		return key(s[i]) < key(s[j])
+	})
+
+	return s
+}
+
+// uuid returns a name-based UUID, version 5 in the URL namespace, of the described content so that documents generated
+// from the same buildpack and dependencies have the same identifier.
+func uuid(b []byte) string {
+	h := sha1.New()
+	h.Write([]byte{0x6b, 0xa7, 0xb8, 0x11, 0x9d, 0xad, 0x11, 0xd1, 0x80, 0xb4, 0x00, 0xc0, 0x4f, 0xd4, 0x30, 0xc8})
+	h.Write(b)
+	s := h.Sum(nil)
+
+	s[6] = (s[6] & 0x0f) | 0x50
+	s[8] = (s[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", s[0:4], s[4:6], s[6:8], s[8:10], s[10:16])
+}
+
+func write(path string, v interface{}) error {
+	buf := &bytes.Buffer{}
+	e := json.NewEncoder(buf)
+	e.SetEscapeHTML(false)
+	e.SetIndent("", "  ")
+
+	if err := e.Encode(v); err != nil {
+		return toolkit.FailedErrorf("unable to encode %s\n%w", path, err)
+	}
+
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return toolkit.FailedErrorf("unable to write %s\n%w", path, err)
+	}
+
+	return nil
+}
+
+type config struct {
+	descriptor.Config
+
+	CycloneDXPath string
+	SPDXPath      string
+	Timestamp     string
+}
+
+func parseConfig(tk toolkit.Toolkit) (config, error) {
+	var c config
+
+	d, err := descriptor.ParseConfig(tk)
+	if err != nil {
+		return config{}, err
+	}
+	c.Config = d
+
+	c.CycloneDXPath = "sbom.cdx.json"
+	if s, ok := tk.GetInput("cyclonedx-path"); ok {
+		c.CycloneDXPath = s
+	}
+
+	c.SPDXPath = "sbom.spdx.json"
+	if s, ok := tk.GetInput("spdx-path"); ok {
+		c.SPDXPath = s
+	}
+
+	if s, ok := tk.GetInput("timestamp"); ok && s != "" {
+		t, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			return config{}, toolkit.FailedErrorf("invalid timestamp %s, must be an RFC 3339 date-time", s)
+		}
+		c.Timestamp = t.UTC().Format(time.RFC3339)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sbom_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	sbom "github.com/buildpacks/github-actions/buildpack/generate-sbom"
+	"github.com/buildpacks/github-actions/internal/toolkit"
+)
+
+func TestGenerateSBOM(t *testing.T) {
+	spec.Run(t, "generate-sbom", func(t *testing.T, context spec.G, it spec.S) {
+		var (
+			Expect = NewWithT(t).Expect
+
+			cyclonedx string
+			spdx      string
+			tk        = &toolkit.MockToolkit{}
+		)
+
+		read := func(path string) string {
+			b, err := os.ReadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			return string(b)
+		}
+
+		it.Before(func() {
+			dir := t.TempDir()
+			cyclonedx = filepath.Join(dir, "sbom.cdx.json")
+			spdx = filepath.Join(dir, "sbom.spdx.json")
+
+			tk.On("GetInput", "kind").Return("", false)
+			tk.On("GetInput", "cyclonedx-path").Return(cyclonedx, true)
+			tk.On("GetInput", "spdx-path").Return(spdx, true)
+			tk.On("SetOutput", "cyclonedx-path", cyclonedx)
+			tk.On("SetOutput", "spdx-path", spdx)
+		})
+
+		context("default timestamp", func() {
+			it.Before(func() {
+				tk.On("GetInput", "timestamp").Return("", false)
+			})
+
+			it("writes CycloneDX and SPDX documents", func() {
+				tk.On("GetInput", "path").Return(filepath.Join("testdata", "buildpack.toml"), true)
+
+				Expect(sbom.GenerateSBOM(tk)).To(Succeed())
+
+				Expect(read(cyclonedx)).To(Equal(read(filepath.Join("testdata", "sbom.cdx.json"))))
+				Expect(read(spdx)).To(Equal(read(filepath.Join("testdata", "sbom.spdx.json"))))
+				tk.AssertCalled(t, "SetOutput", "cyclonedx-path", cyclonedx)
+				tk.AssertCalled(t, "SetOutput", "spdx-path", spdx)
+			})
+
+			it("does not depend on the order of dependencies", func() {
+				s := read(filepath.Join("testdata", "buildpack.toml"))
+				i := strings.Index(s, "[[metadata.dependencies]]")
+				j := strings.LastIndex(s, "[[metadata.dependencies]]")
+
+				path := filepath.Join(t.TempDir(), "buildpack.toml")
+				Expect(os.WriteFile(path, []byte(s[:i]+s[j:]+"\n"+s[i:j]), 0644)).To(Succeed())
+				tk.On("GetInput", "path").Return(path, true)
+
+				Expect(sbom.GenerateSBOM(tk)).To(Succeed())
+
+				Expect(read(cyclonedx)).To(Equal(read(filepath.Join("testdata", "sbom.cdx.json"))))
+				Expect(read(spdx)).To(Equal(read(filepath.Join("testdata", "sbom.spdx.json"))))
+			})
+		})
+
+		it("sets timestamp", func() {
+			tk.On("GetInput", "path").Return(filepath.Join("testdata", "buildpack.toml"), true)
+			tk.On("GetInput", "timestamp").Return("2021-02-03T04:05:06+01:00", true)
+
+			Expect(sbom.GenerateSBOM(tk)).To(Succeed())
+
+			Expect(read(cyclonedx)).To(ContainSubstring(`"timestamp": "2021-02-03T03:05:06Z"`))
+			Expect(read(spdx)).To(ContainSubstring(`"created": "2021-02-03T03:05:06Z"`))
+		})
+
+		it("fails with invalid timestamp", func() {
+			tk.On("GetInput", "path").Return(filepath.Join("testdata", "buildpack.toml"), true)
+			tk.On("GetInput", "timestamp").Return("yesterday", true)
+
+			Expect(sbom.GenerateSBOM(tk)).To(MatchError("::error ::invalid timestamp yesterday, must be an RFC 3339 date-time"))
+		})
+	}, spec.Report(report.Terminal{}))
+}
@@ -0,0 +1,177 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sbom
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/buildpacks/libcnb"
+
+	"github.com/buildpacks/github-actions/buildpack/internal/descriptor"
+)
+
+// NoAssertion is the SPDX value for information that is not known.
+const NoAssertion = "NOASSERTION"
+
+// SPDX is an SPDX 2.3 JSON document.
+type SPDX struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      SPDXCreationInfo   `json:"creationInfo"`
+	Packages          []SPDXPackage      `json:"packages"`
+	Relationships     []SPDXRelationship `json:"relationships"`
+}
+
+type SPDXCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type SPDXPackage struct {
+	SPDXID                string            `json:"SPDXID"`
+	Name                  string            `json:"name"`
+	VersionInfo           string            `json:"versionInfo,omitempty"`
+	DownloadLocation      string            `json:"downloadLocation"`
+	Homepage              string            `json:"homepage,omitempty"`
+	Description           string            `json:"description,omitempty"`
+	Checksums             []SPDXChecksum    `json:"checksums,omitempty"`
+	LicenseConcluded      string            `json:"licenseConcluded"`
+	LicenseDeclared       string            `json:"licenseDeclared"`
+	CopyrightText         string            `json:"copyrightText"`
+	FilesAnalyzed         bool              `json:"filesAnalyzed"`
+	ExternalRefs          []SPDXExternalRef `json:"externalRefs,omitempty"`
+	PrimaryPackagePurpose string            `json:"primaryPackagePurpose"`
+}
+
+type SPDXChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+type SPDXExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type SPDXRelationship struct {
+	SPDXElementID      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSPDXElement string `json:"relatedSpdxElement"`
+}
+
+// NewSPDX returns an SPDX document describing a buildpack package that depends on a package for each of its
+// dependencies.  The creation time defaults to the Unix epoch so that the document is deterministic.
+func NewSPDX(info libcnb.BuildpackInfo, dependencies []descriptor.Dependency, serial string, timestamp string) SPDX {
+	if timestamp == "" {
+		timestamp = "1970-01-01T00:00:00Z"
+	}
+
+	var types []string
+	for _, l := range info.Licenses {
+		types = append(types, l.Type)
+	}
+
+	root := SPDXPackage{
+		SPDXID:                "SPDXRef-Buildpack",
+		Name:                  info.ID,
+		VersionInfo:           info.Version,
+		DownloadLocation:      NoAssertion,
+		Homepage:              info.Homepage,
+		Description:           info.Description,
+		LicenseConcluded:      NoAssertion,
+		LicenseDeclared:       spdxLicense(types),
+		CopyrightText:         NoAssertion,
+		PrimaryPackagePurpose: "APPLICATION",
+	}
+
+	s := SPDX{
+		SPDXVersion:       "SPDX-2.3",
+		DataLicense:       "CC0-1.0",
+		SPDXID:            "SPDXRef-DOCUMENT",
+		Name:              fmt.Sprintf("%s@%s", info.ID, info.Version),
+		DocumentNamespace: fmt.Sprintf("https://buildpacks.io/spdx/%s/%s/%s", url.PathEscape(info.ID), url.PathEscape(info.Version), serial),
+		CreationInfo: SPDXCreationInfo{
+			Created:  timestamp,
+			Creators: []string{"Organization: Cloud Native Buildpacks", "Tool: buildpacks-github-actions"},
+		},
+		Packages: []SPDXPackage{root},
+		Relationships: []SPDXRelationship{
+			{SPDXElementID: "SPDXRef-DOCUMENT", RelationshipType: "DESCRIBES", RelatedSPDXElement: root.SPDXID},
+		},
+	}
+
+	for i, d := range dependencies {
+		p := SPDXPackage{
+			SPDXID:                fmt.Sprintf("SPDXRef-Dependency-%d", i+1),
+			Name:                  d.ID,
+			VersionInfo:           d.Version,
+			DownloadLocation:      NoAssertion,
+			Description:           d.Name,
+			LicenseConcluded:      NoAssertion,
+			LicenseDeclared:       spdxLicense(d.LicenseTypes()),
+			CopyrightText:         NoAssertion,
+			PrimaryPackagePurpose: "LIBRARY",
+		}
+
+		if d.URI != "" {
+			p.DownloadLocation = d.URI
+		}
+
+		if d.SHA256 != "" {
+			p.Checksums = []SPDXChecksum{{Algorithm: "SHA256", ChecksumValue: d.SHA256}}
+		}
+
+		if d.PURL != "" {
+			p.ExternalRefs = append(p.ExternalRefs, SPDXExternalRef{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: d.PURL})
+		}
+
+		for _, cpe := range d.CPEs {
+			t := "cpe23Type"
+			if !strings.HasPrefix(cpe, "cpe:2.3:") {
+				t = "cpe22Type"
+			}
+			p.ExternalRefs = append(p.ExternalRefs, SPDXExternalRef{ReferenceCategory: "SECURITY", ReferenceType: t, ReferenceLocator: cpe})
+		}
+
+		s.Packages = append(s.Packages, p)
+		s.Relationships = append(s.Relationships, SPDXRelationship{SPDXElementID: root.SPDXID, RelationshipType: "DEPENDS_ON", RelatedSPDXElement: p.SPDXID})
+	}
+
+	return s
+}
+
+// spdxLicense returns the conjunction of license types, or NOASSERTION if there are none or any is not a known SPDX
+// license identifier.
+func spdxLicense(types []string) string {
+	if len(types) == 0 {
+		return NoAssertion
+	}
+
+	for _, t := range types {
+		if !descriptor.SPDXLicense(t) {
+			return NoAssertion
+		}
+	}
+
+	return strings.Join(types, " AND ")
+}
@@ -0,0 +1,48 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package descriptor
+
+import (
+	"github.com/buildpacks/github-actions/internal/toolkit"
+)
+
+// Config is the kind and path of the descriptor an action reads.
+type Config struct {
+	Kind string
+	Path string
+}
+
+// ParseConfig reads the kind and path inputs.  If path is not set, it defaults to the descriptor of kind in the working
+// directory.
+func ParseConfig(tk toolkit.Toolkit) (Config, error) {
+	var c Config
+
+	if s, ok := tk.GetInput("kind"); ok {
+		c.Kind = s
+	}
+
+	if !ValidKind(c.Kind) {
+		return Config{}, toolkit.FailedErrorf("invalid kind %s, must be %s or %s", c.Kind, KindBuildpack, KindExtension)
+	}
+
+	c.Path = DefaultPath("", c.Kind)
+	if s, ok := tk.GetInput("path"); ok {
+		c.Path = s
+	}
+
+	return c, nil
+}
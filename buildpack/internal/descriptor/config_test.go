@@ -0,0 +1,59 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package descriptor_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/github-actions/buildpack/internal/descriptor"
+	"github.com/buildpacks/github-actions/internal/toolkit"
+)
+
+func TestConfig(t *testing.T) {
+	spec.Run(t, "config", func(t *testing.T, context spec.G, it spec.S) {
+		var (
+			Expect = NewWithT(t).Expect
+
+			tk = &toolkit.MockToolkit{}
+		)
+
+		it("defaults path to descriptor of kind", func() {
+			tk.On("GetInput", "kind").Return("extension", true)
+			tk.On("GetInput", "path").Return("", false)
+
+			Expect(descriptor.ParseConfig(tk)).To(Equal(descriptor.Config{Kind: descriptor.KindExtension, Path: "extension.toml"}))
+		})
+
+		it("reads path", func() {
+			tk.On("GetInput", "kind").Return("", false)
+			tk.On("GetInput", "path").Return("test-path", true)
+
+			Expect(descriptor.ParseConfig(tk)).To(Equal(descriptor.Config{Path: "test-path"}))
+		})
+
+		it("fails on invalid kind", func() {
+			tk.On("GetInput", "kind").Return("builder", true)
+
+			_, err := descriptor.ParseConfig(tk)
+			Expect(err).To(MatchError("::error ::invalid kind builder, must be buildpack or extension"))
+		})
+	}, spec.Report(report.Terminal{}))
+}
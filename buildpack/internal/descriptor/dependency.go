@@ -0,0 +1,84 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package descriptor
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// Metadata is the [metadata] table of a descriptor.  Only dependencies are read, other keys are ignored.
+type Metadata struct {
+	Dependencies []Dependency `toml:"dependencies"`
+}
+
+// Dependency is an entry of [[metadata.dependencies]].
+type Dependency struct {
+	ID              string        `toml:"id"`
+	Name            string        `toml:"name"`
+	Version         string        `toml:"version"`
+	URI             string        `toml:"uri"`
+	SHA256          string        `toml:"sha256"`
+	Stacks          []string      `toml:"stacks"`
+	CPEs            []string      `toml:"cpes"`
+	PURL            string        `toml:"purl"`
+	Licenses        []interface{} `toml:"licenses"`
+	DeprecationDate interface{}   `toml:"deprecation_date"`
+}
+
+// Deprecation returns the deprecation_date of a dependency, which may be a TOML date, date-time, or a string in
+// either format.  It returns false if the dependency has no deprecation_date.
+func (d Dependency) Deprecation() (time.Time, bool, error) {
+	switch v := d.DeprecationDate.(type) {
+	case nil:
+		return time.Time{}, false, nil
+	case time.Time:
+		return v, true, nil
+	case toml.LocalDate:
+		return v.AsTime(time.UTC), true, nil
+	case toml.LocalDateTime:
+		return v.AsTime(time.UTC), true, nil
+	case string:
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t, true, nil
+		}
+		if t, err := time.Parse("2006-01-02", v); err == nil {
+			return t, true, nil
+		}
+	}
+
+	return time.Time{}, false, fmt.Errorf("dependency %s@%s has invalid deprecation_date %v", d.ID, d.Version, d.DeprecationDate)
+}
+
+// LicenseTypes returns the license types of a dependency, whose licenses may be strings or tables with a type.
+func (d Dependency) LicenseTypes() []string {
+	var types []string
+	for _, l := range d.Licenses {
+		switch v := l.(type) {
+		case string:
+			types = append(types, v)
+		case map[string]interface{}:
+			if t, ok := v["type"].(string); ok && t != "" {
+				types = append(types, t)
+			}
+		}
+	}
+
+	return types
+}
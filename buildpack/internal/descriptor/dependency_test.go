@@ -0,0 +1,104 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package descriptor_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/github-actions/buildpack/internal/descriptor"
+)
+
+func TestDependency(t *testing.T) {
+	spec.Run(t, "dependency", func(t *testing.T, context spec.G, it spec.S) {
+		var (
+			Expect = NewWithT(t).Expect
+		)
+
+		it("reads dependencies", func() {
+			path := filepath.Join(t.TempDir(), "buildpack.toml")
+			Expect(os.WriteFile(path, []byte(`[buildpack]
+id = "test-id"
+
+[metadata]
+include-files = ["buildpack.toml"]
+
+[[metadata.dependencies]]
+id               = "test-dependency-1"
+version          = "1.2.3"
+uri              = "https://example.com/test-dependency-1.tgz"
+sha256           = "test-sha256"
+stacks           = ["*"]
+cpes             = ["cpe:2.3:a:example:test:1.2.3:*:*:*:*:*:*:*"]
+purl             = "pkg:generic/test@1.2.3"
+deprecation_date = 2030-01-02T00:00:00Z
+
+[[metadata.dependencies]]
+id               = "test-dependency-2"
+version          = "1.2.3"
+deprecation_date = 2030-01-02
+
+[[metadata.dependencies]]
+id               = "test-dependency-3"
+version          = "1.2.3"
+deprecation_date = "2030-01-02"
+
+[[metadata.dependencies]]
+id      = "test-dependency-4"
+version = "1.2.3"
+`), 0644)).To(Succeed())
+
+			d, err := descriptor.Read(path, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(d.Metadata.Dependencies).To(HaveLen(4))
+			Expect(d.Metadata.Dependencies[0].PURL).To(Equal("pkg:generic/test@1.2.3"))
+
+			expected := time.Date(2030, 1, 2, 0, 0, 0, 0, time.UTC)
+			for _, dep := range d.Metadata.Dependencies[:3] {
+				t, ok, err := dep.Deprecation()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ok).To(BeTrue())
+				Expect(t.Equal(expected)).To(BeTrue())
+			}
+
+			_, ok, err := d.Metadata.Dependencies[3].Deprecation()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeFalse())
+		})
+
+		it("fails on invalid deprecation date", func() {
+			_, _, err := descriptor.Dependency{ID: "test-id", Version: "1.2.3", DeprecationDate: "tomorrow"}.Deprecation()
+			Expect(err).To(MatchError("dependency test-id@1.2.3 has invalid deprecation_date tomorrow"))
+		})
+
+		it("returns license types", func() {
+			dep := descriptor.Dependency{Licenses: []interface{}{
+				"MIT",
+				map[string]interface{}{"type": "Apache-2.0", "uri": "https://www.apache.org/licenses/LICENSE-2.0"},
+				map[string]interface{}{"uri": "https://example.com/license"},
+			}}
+
+			Expect(dep.LicenseTypes()).To(Equal([]string{"MIT", "Apache-2.0"}))
+		})
+	}, spec.Report(report.Terminal{}))
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package descriptor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/buildpacks/libcnb"
+	"github.com/pelletier/go-toml/v2"
+)
+
+const (
+	KindBuildpack = "buildpack"
+	KindExtension = "extension"
+)
+
+// Descriptor is the contents of a buildpack.toml or an extension.toml.
+type Descriptor struct {
+	API       string                  `toml:"api"`
+	Buildpack *libcnb.BuildpackInfo   `toml:"buildpack"`
+	Extension *libcnb.BuildpackInfo   `toml:"extension"`
+	Stacks    []libcnb.BuildpackStack `toml:"stacks"`
+	Targets   []Target                `toml:"targets"`
+	Order     []libcnb.BuildpackOrder `toml:"order"`
+	Metadata  Metadata                `toml:"metadata"`
+
+	Kind string `toml:"-"`
+	Path string `toml:"-"`
+}
+
+// Target is an os, architecture and distribution a buildpack supports.
+type Target struct {
+	OS      string   `toml:"os" json:"os"`
+	Arch    string   `toml:"arch" json:"arch"`
+	Variant string   `toml:"variant" json:"variant,omitempty"`
+	Distros []Distro `toml:"distros" json:"distros,omitempty"`
+}
+
+type Distro struct {
+	Name    string `toml:"name" json:"name"`
+	Version string `toml:"version" json:"version,omitempty"`
+}
+
+// Info returns the [buildpack] or [extension] table of the descriptor.
+func (d Descriptor) Info() libcnb.BuildpackInfo {
+	if d.Extension != nil {
+		return *d.Extension
+	}
+
+	if d.Buildpack != nil {
+		return *d.Buildpack
+	}
+
+	return libcnb.BuildpackInfo{}
+}
+
+// DefaultPath returns the descriptor file name of a kind.  If kind is empty, buildpack.toml is preferred if it exists
+// in dir and extension.toml is used otherwise.
+func DefaultPath(dir string, kind string) string {
+	switch kind {
+	case KindBuildpack:
+		return filepath.Join(dir, "buildpack.toml")
+	case KindExtension:
+		return filepath.Join(dir, "extension.toml")
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "extension.toml")); err == nil {
+		if _, err := os.Stat(filepath.Join(dir, "buildpack.toml")); os.IsNotExist(err) {
+			return filepath.Join(dir, "extension.toml")
+		}
+	}
+
+	return filepath.Join(dir, "buildpack.toml")
+}
+
+// Read reads a descriptor of a kind.  If kind is empty, it is detected from the [buildpack] or [extension] table.
+func Read(path string, kind string) (Descriptor, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Descriptor{}, fmt.Errorf("unable to read %s\n%w", path, err)
+	}
+
+	var d Descriptor
+	if err := toml.Unmarshal(b, &d); err != nil {
+		return Descriptor{}, fmt.Errorf("unable to unmarshal %s\n%w", path, err)
+	}
+	d.Path = path
+
+	switch {
+	case d.Buildpack != nil && d.Extension != nil:
+		return Descriptor{}, fmt.Errorf("%s must not have both [buildpack] and [extension] tables", path)
+	case d.Buildpack != nil:
+		d.Kind = KindBuildpack
+	case d.Extension != nil:
+		d.Kind = KindExtension
+	default:
+		return Descriptor{}, fmt.Errorf("%s must have a [buildpack] or [extension] table", path)
+	}
+
+	if kind != "" && kind != d.Kind {
+		return Descriptor{}, fmt.Errorf("%s has kind %s, expected %s", path, d.Kind, kind)
+	}
+
+	return d, nil
+}
+
+// ValidKind returns whether kind is empty or a known descriptor kind.
+func ValidKind(kind string) bool {
+	return kind == "" || kind == KindBuildpack || kind == KindExtension
+}
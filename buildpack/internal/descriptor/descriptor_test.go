@@ -0,0 +1,86 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package descriptor_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/github-actions/buildpack/internal/descriptor"
+)
+
+func TestDescriptor(t *testing.T) {
+	spec.Run(t, "descriptor", func(t *testing.T, context spec.G, it spec.S) {
+		var (
+			Expect = NewWithT(t).Expect
+		)
+
+		it("reads buildpack", func() {
+			d, err := descriptor.Read(filepath.Join("testdata", "buildpack.toml"), "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(d.Kind).To(Equal(descriptor.KindBuildpack))
+			Expect(d.API).To(Equal("0.10"))
+			Expect(d.Info().ID).To(Equal("test-id"))
+		})
+
+		it("reads extension", func() {
+			d, err := descriptor.Read(filepath.Join("testdata", "extension.toml"), "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(d.Kind).To(Equal(descriptor.KindExtension))
+			Expect(d.Info().ID).To(Equal("test-extension-id"))
+		})
+
+		it("fails if kind does not match", func() {
+			_, err := descriptor.Read(filepath.Join("testdata", "extension.toml"), descriptor.KindBuildpack)
+			Expect(err).To(MatchError(filepath.Join("testdata", "extension.toml") + " has kind extension, expected buildpack"))
+		})
+
+		it("fails without buildpack or extension table", func() {
+			path := filepath.Join(t.TempDir(), "buildpack.toml")
+			Expect(os.WriteFile(path, []byte(`api = "0.10"`), 0644)).To(Succeed())
+
+			_, err := descriptor.Read(path, "")
+			Expect(err).To(MatchError(path + " must have a [buildpack] or [extension] table"))
+		})
+
+		context("default path", func() {
+			var dir string
+
+			it.Before(func() {
+				dir = t.TempDir()
+			})
+
+			it("uses kind", func() {
+				Expect(descriptor.DefaultPath(dir, descriptor.KindExtension)).To(Equal(filepath.Join(dir, "extension.toml")))
+			})
+
+			it("prefers buildpack.toml", func() {
+				Expect(descriptor.DefaultPath(dir, "")).To(Equal(filepath.Join(dir, "buildpack.toml")))
+			})
+
+			it("uses extension.toml if it is the only descriptor", func() {
+				Expect(os.WriteFile(filepath.Join(dir, "extension.toml"), []byte{}, 0644)).To(Succeed())
+				Expect(descriptor.DefaultPath(dir, "")).To(Equal(filepath.Join(dir, "extension.toml")))
+			})
+		})
+	}, spec.Report(report.Terminal{}))
+}
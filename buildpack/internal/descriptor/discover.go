@@ -0,0 +1,63 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package descriptor
+
+import (
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+)
+
+// Discover reads every buildpack.toml and extension.toml below dir, skipping hidden directories.  If kind is set,
+// descriptors of other kinds are ignored.  Descriptors are returned in the lexical order of their paths.
+func Discover(dir string, kind string) ([]Descriptor, error) {
+	var descriptors []Descriptor
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if path != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if d.Name() != "buildpack.toml" && d.Name() != "extension.toml" {
+			return nil
+		}
+
+		desc, err := Read(path, "")
+		if err != nil {
+			return err
+		}
+
+		if kind == "" || desc.Kind == kind {
+			descriptors = append(descriptors, desc)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to discover descriptors in %s\n%w", dir, err)
+	}
+
+	return descriptors, nil
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package descriptor_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/github-actions/buildpack/internal/descriptor"
+)
+
+func TestDiscover(t *testing.T) {
+	spec.Run(t, "discover", func(t *testing.T, context spec.G, it spec.S) {
+		var (
+			Expect = NewWithT(t).Expect
+
+			dir = filepath.Join("testdata", "discover")
+		)
+
+		ids := func(descriptors []descriptor.Descriptor) []string {
+			var ids []string
+			for _, d := range descriptors {
+				ids = append(ids, d.Info().ID)
+			}
+			return ids
+		}
+
+		it("discovers descriptors", func() {
+			d, err := descriptor.Discover(dir, "")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(d)).To(Equal([]string{"test/a", "test/b", "test/ext", "test/meta"}))
+			Expect(d[0].Path).To(Equal(filepath.Join(dir, "a", "buildpack.toml")))
+		})
+
+		it("filters by kind", func() {
+			d, err := descriptor.Discover(dir, descriptor.KindExtension)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids(d)).To(Equal([]string{"test/ext"}))
+		})
+	}, spec.Report(report.Terminal{}))
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package descriptor
+
+// licenses are the SPDX license identifiers that are known to the actions.
+var licenses = map[string]bool{
+	"0BSD":               true,
+	"AFL-3.0":            true,
+	"AGPL-3.0":           true,
+	"AGPL-3.0-only":      true,
+	"AGPL-3.0-or-later":  true,
+	"Apache-1.1":         true,
+	"Apache-2.0":         true,
+	"APSL-2.0":           true,
+	"Artistic-2.0":       true,
+	"BlueOak-1.0.0":      true,
+	"BSD-1-Clause":       true,
+	"BSD-2-Clause":       true,
+	"BSD-3-Clause":       true,
+	"BSD-3-Clause-Clear": true,
+	"BSD-4-Clause":       true,
+	"BSL-1.0":            true,
+	"BUSL-1.1":           true,
+	"CC-BY-4.0":          true,
+	"CC-BY-SA-4.0":       true,
+	"CC0-1.0":            true,
+	"CDDL-1.0":           true,
+	"CDDL-1.1":           true,
+	"CPL-1.0":            true,
+	"ECL-2.0":            true,
+	"EPL-1.0":            true,
+	"EPL-2.0":            true,
+	"EUPL-1.1":           true,
+	"EUPL-1.2":           true,
+	"GPL-2.0":            true,
+	"GPL-2.0-only":       true,
+	"GPL-2.0-or-later":   true,
+	"GPL-3.0":            true,
+	"GPL-3.0-only":       true,
+	"GPL-3.0-or-later":   true,
+	"ISC":                true,
+	"LGPL-2.0-only":      true,
+	"LGPL-2.0-or-later":  true,
+	"LGPL-2.1":           true,
+	"LGPL-2.1-only":      true,
+	"LGPL-2.1-or-later":  true,
+	"LGPL-3.0":           true,
+	"LGPL-3.0-only":      true,
+	"LGPL-3.0-or-later":  true,
+	"MIT":                true,
+	"MIT-0":              true,
+	"MPL-1.1":            true,
+	"MPL-2.0":            true,
+	"MS-PL":              true,
+	"MS-RL":              true,
+	"NCSA":               true,
+	"OFL-1.1":            true,
+	"OpenSSL":            true,
+	"OSL-3.0":            true,
+	"PHP-3.01":           true,
+	"PostgreSQL":         true,
+	"Python-2.0":         true,
+	"Ruby":               true,
+	"Unlicense":          true,
+	"UPL-1.0":            true,
+	"Vim":                true,
+	"W3C":                true,
+	"WTFPL":              true,
+	"X11":                true,
+	"Zlib":               true,
+	"ZPL-2.1":            true,
+}
+
+// SPDXLicense returns whether a license type is a known SPDX license identifier.
+func SPDXLicense(id string) bool {
+	return licenses[id]
+}
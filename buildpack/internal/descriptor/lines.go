@@ -0,0 +1,126 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package descriptor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2/unstable"
+)
+
+// Lines maps the keys and tables of a TOML document to the line they are defined on.  Keys are dotted paths with the
+// index of arrays of tables, e.g. buildpack.id or order[0].group[1].id.
+type Lines map[string]int
+
+// ParseLines returns the lines of the keys and tables of a TOML document.
+func ParseLines(b []byte) (Lines, error) {
+	lines := Lines{}
+
+	if err := walk(b, func(path string, line int, _ *unstable.Node) {
+		if _, ok := lines[path]; !ok {
+			lines[path] = line
+		}
+	}); err != nil {
+		return nil, err
+	}
+
+	return lines, nil
+}
+
+// Line returns the line of a key, or of its closest enclosing table if the key is not defined.  It returns 1 if
+// neither is defined.
+func (l Lines) Line(path string) int {
+	for path != "" {
+		if n, ok := l[path]; ok {
+			return n
+		}
+
+		if i := strings.LastIndexAny(path, ".["); i >= 0 {
+			path = path[:i]
+		} else {
+			path = ""
+		}
+	}
+
+	return 1
+}
+
+func join(base string, key string) string {
+	if base == "" {
+		return key
+	}
+
+	return fmt.Sprintf("%s.%s", base, key)
+}
+
+// walk calls fn with the path and line of every table and key of a TOML document.  The value is nil for tables.
+func walk(b []byte, fn func(path string, line int, value *unstable.Node)) error {
+	var (
+		counts = map[string]int{}
+		table  string
+		p      unstable.Parser
+	)
+
+	resolve := func(base string, key unstable.Iterator, array bool) (string, int) {
+		var (
+			path  = base
+			line  int
+			parts []string
+		)
+
+		for key.Next() {
+			n := key.Node()
+			if line == 0 {
+				line = p.Shape(p.Range(n.Data)).Start.Line
+			}
+			parts = append(parts, string(n.Data))
+		}
+
+		for i, part := range parts {
+			path = join(path, part)
+
+			if array && i == len(parts)-1 {
+				fn(path, line, nil)
+				c := counts[path]
+				counts[path] = c + 1
+				path = fmt.Sprintf("%s[%d]", path, c)
+			} else if c, ok := counts[path]; ok {
+				path = fmt.Sprintf("%s[%d]", path, c-1)
+			}
+		}
+
+		return path, line
+	}
+
+	p.Reset(b)
+	for p.NextExpression() {
+		e := p.Expression()
+
+		switch e.Kind {
+		case unstable.Table, unstable.ArrayTable:
+			path, line := resolve("", e.Key(), e.Kind == unstable.ArrayTable)
+			table = path
+			fn(path, line, nil)
+		case unstable.KeyValue:
+			path, line := resolve(table, e.Key(), false)
+			fn(path, line, e.Value())
+		}
+	}
+
+	return p.Error()
+}
@@ -0,0 +1,87 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package descriptor_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/github-actions/buildpack/internal/descriptor"
+)
+
+func TestLines(t *testing.T) {
+	spec.Run(t, "lines", func(t *testing.T, context spec.G, it spec.S) {
+		var (
+			Expect = NewWithT(t).Expect
+		)
+
+		it("maps keys to lines", func() {
+			lines, err := descriptor.ParseLines([]byte(`api = "0.10"
+
+[buildpack]
+id = "test-id"
+licenses = [{ type = "MIT" }]
+
+[[order]]
+[[order.group]]
+id = "test-group-id-1"
+
+[[order.group]]
+id = "test-group-id-2"
+
+[[order]]
+[[order.group]]
+id = "test-group-id-3"
+`))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(lines).To(Equal(descriptor.Lines{
+				"api":                  1,
+				"buildpack":            3,
+				"buildpack.id":         4,
+				"buildpack.licenses":   5,
+				"order":                7,
+				"order[0]":             7,
+				"order[0].group":       8,
+				"order[0].group[0]":    8,
+				"order[0].group[0].id": 9,
+				"order[0].group[1]":    11,
+				"order[0].group[1].id": 12,
+				"order[1]":             14,
+				"order[1].group":       15,
+				"order[1].group[0]":    15,
+				"order[1].group[0].id": 16,
+			}))
+		})
+
+		it("falls back to enclosing table", func() {
+			lines := descriptor.Lines{"buildpack": 3, "order[0]": 7}
+
+			Expect(lines.Line("buildpack.version")).To(Equal(3))
+			Expect(lines.Line("order[0].group")).To(Equal(7))
+			Expect(lines.Line("stacks[0].id")).To(Equal(1))
+		})
+
+		it("fails on invalid TOML", func() {
+			_, err := descriptor.ParseLines([]byte(`api = `))
+			Expect(err).To(HaveOccurred())
+		})
+	}, spec.Report(report.Terminal{}))
+}
@@ -0,0 +1,203 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package descriptor
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2/unstable"
+
+	"github.com/buildpacks/github-actions/internal/toolkit"
+)
+
+// Value is a string value of a TOML document and the offsets of its quoted form in the document.
+type Value struct {
+	Value   string
+	Start   int
+	End     int
+	Literal bool
+}
+
+// Values maps the keys of a TOML document to their string values.  Keys are dotted paths as in Lines, with the index
+// of the elements of arrays and the keys of inline tables, e.g. metadata.dependencies[0].cpes[1] or
+// order[0].group[1].version for group = [{ id = "…", version = "…" }].
+type Values map[string]Value
+
+// ParseValues returns the string values of a TOML document.
+func ParseValues(b []byte) (Values, error) {
+	values := Values{}
+
+	newValue := func(n *unstable.Node) Value {
+		start := int(n.Raw.Offset)
+		return Value{
+			Value:   string(n.Data),
+			Start:   start,
+			End:     start + int(n.Raw.Length),
+			Literal: b[start] == '\'',
+		}
+	}
+
+	var add func(path string, n *unstable.Node)
+	add = func(path string, n *unstable.Node) {
+		switch n.Kind {
+		case unstable.String:
+			values[path] = newValue(n)
+		case unstable.Array:
+			i := 0
+			for it := n.Children(); it.Next(); i++ {
+				add(fmt.Sprintf("%s[%d]", path, i), it.Node())
+			}
+		case unstable.InlineTable:
+			for it := n.Children(); it.Next(); {
+				kv := it.Node()
+
+				p := path
+				for key := kv.Key(); key.Next(); {
+					p = join(p, string(key.Node().Data))
+				}
+				add(p, kv.Value())
+			}
+		}
+	}
+
+	if err := walk(b, func(path string, _ int, value *unstable.Node) {
+		if value != nil {
+			add(path, value)
+		}
+	}); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// Replace returns a TOML document with string values replaced and every other byte, including formatting and comments,
+// left as is.  Each key of replacements must be a key of values.  Replacements keep literal quoting where possible.
+func (v Values) Replace(b []byte, replacements map[string]string) ([]byte, error) {
+	var edits []Value
+	for k, s := range replacements {
+		e, ok := v[k]
+		if !ok {
+			return nil, fmt.Errorf("%s is not a string value", k)
+		}
+
+		e.Value = s
+		edits = append(edits, e)
+	}
+
+	sort.Slice(edits, func(i, j int) bool {
+		return edits[i].Start > edits[j].Start
+	})
+
+	out := append([]byte{}, b...)
+	for _, e := range edits {
+		out = append(out[:e.Start], append([]byte(quote(e.Value, e.Literal)), out[e.End:]...)...)
+	}
+
+	return out, nil
+}
+
+// Edit is a TOML document with string values replaced that has not been written yet.
+type Edit struct {
+	Path    string
+	Content []byte
+}
+
+// Prepare returns the TOML document at path with string values replaced, without writing it.  replacements is called
+// with the values of the document and returns the values to replace, as in Replace.
+func Prepare(path string, replacements func(Values) (map[string]string, error)) (Edit, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Edit{}, toolkit.FailedErrorf("unable to read %s\n%w", path, err)
+	}
+
+	values, err := ParseValues(b)
+	if err != nil {
+		return Edit{}, toolkit.FailedErrorf("unable to parse %s\n%w", path, err)
+	}
+
+	r, err := replacements(values)
+	if err != nil {
+		return Edit{}, err
+	}
+
+	if b, err = values.Replace(b, r); err != nil {
+		return Edit{}, toolkit.FailedErrorf("unable to update %s\n%w", path, err)
+	}
+
+	return Edit{Path: path, Content: b}, nil
+}
+
+// Write writes the document in place, keeping the mode of the file.
+func (e Edit) Write() error {
+	info, err := os.Stat(e.Path)
+	if err != nil {
+		return toolkit.FailedErrorf("unable to stat %s\n%w", e.Path, err)
+	}
+
+	if err := os.WriteFile(e.Path, e.Content, info.Mode()); err != nil {
+		return toolkit.FailedErrorf("unable to write %s\n%w", e.Path, err)
+	}
+
+	return nil
+}
+
+// Rewrite replaces string values of the TOML document at path in place, keeping the mode of the file.  replacements
+// is called with the values of the document and returns the values to replace, as in Replace.
+func Rewrite(path string, replacements func(Values) (map[string]string, error)) error {
+	e, err := Prepare(path, replacements)
+	if err != nil {
+		return err
+	}
+
+	return e.Write()
+}
+
+func quote(s string, literal bool) string {
+	if literal && !strings.ContainsAny(s, "'\n\r") {
+		return fmt.Sprintf("'%s'", s)
+	}
+
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			if r < 0x20 || r == 0x7f {
+				fmt.Fprintf(&sb, `\u%04X`, r)
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+	sb.WriteByte('"')
+
+	return sb.String()
+}
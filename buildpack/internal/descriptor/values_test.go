@@ -0,0 +1,146 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package descriptor_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/github-actions/buildpack/internal/descriptor"
+)
+
+func TestValues(t *testing.T) {
+	spec.Run(t, "values", func(t *testing.T, context spec.G, it spec.S) {
+		var (
+			Expect = NewWithT(t).Expect
+
+			document = []byte(`# test comment
+api = "0.8"
+
+[buildpack]
+  id      = "test-id"   # trailing comment
+  version = '1.2.3'
+
+[[metadata.dependencies]]
+id     = "test-dependency"
+cpes   = ["cpe:test:1.2.3", 'cpe:other:1.2.3']
+`)
+		)
+
+		it("maps keys to string values", func() {
+			values, err := descriptor.ParseValues(document)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(values).To(HaveLen(6))
+			Expect(values["api"].Value).To(Equal("0.8"))
+			Expect(values["buildpack.id"].Value).To(Equal("test-id"))
+			Expect(values["buildpack.version"].Literal).To(BeTrue())
+			Expect(values["metadata.dependencies[0].id"].Value).To(Equal("test-dependency"))
+			Expect(values["metadata.dependencies[0].cpes[1]"].Value).To(Equal("cpe:other:1.2.3"))
+			Expect(string(document[values["buildpack.id"].Start:values["buildpack.id"].End])).To(Equal(`"test-id"`))
+		})
+
+		it("replaces values in place", func() {
+			values, err := descriptor.ParseValues(document)
+			Expect(err).NotTo(HaveOccurred())
+
+			b, err := values.Replace(document, map[string]string{
+				"buildpack.id":                     `test-"quoted"-id`,
+				"buildpack.version":                "2.0.0",
+				"metadata.dependencies[0].cpes[0]": "cpe:test:2.0.0",
+				"metadata.dependencies[0].cpes[1]": "it's",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(string(b)).To(Equal(`# test comment
+api = "0.8"
+
+[buildpack]
+  id      = "test-\"quoted\"-id"   # trailing comment
+  version = '2.0.0'
+
+[[metadata.dependencies]]
+id     = "test-dependency"
+cpes   = ["cpe:test:2.0.0", "it's"]
+`))
+		})
+
+		it("maps keys of inline tables", func() {
+			b := []byte(`[[order]]
+group = [{ id = "test-id", version = "1.2.3" }, { id = "other-id", version = '2.0.0', optional = true }]
+`)
+
+			values, err := descriptor.ParseValues(b)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(values).To(HaveLen(4))
+			Expect(values["order[0].group[0].id"].Value).To(Equal("test-id"))
+			Expect(values["order[0].group[1].version"].Literal).To(BeTrue())
+
+			b, err = values.Replace(b, map[string]string{"order[0].group[0].version": "1.3.0"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(Equal(`[[order]]
+group = [{ id = "test-id", version = "1.3.0" }, { id = "other-id", version = '2.0.0', optional = true }]
+`))
+		})
+
+		it("fails for unknown keys", func() {
+			values, err := descriptor.ParseValues(document)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = values.Replace(document, map[string]string{"buildpack.name": "test-name"})
+			Expect(err).To(MatchError("buildpack.name is not a string value"))
+		})
+
+		it("rewrites file in place", func() {
+			path := filepath.Join(t.TempDir(), "buildpack.toml")
+			Expect(os.WriteFile(path, document, 0600)).To(Succeed())
+
+			Expect(descriptor.Rewrite(path, func(values descriptor.Values) (map[string]string, error) {
+				Expect(values).To(HaveKey("buildpack.version"))
+				return map[string]string{"buildpack.version": "2.0.0"}, nil
+			})).To(Succeed())
+
+			b, err := os.ReadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(ContainSubstring(`version = '2.0.0'`))
+
+			info, err := os.Stat(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(info.Mode().Perm()).To(Equal(os.FileMode(0600)))
+		})
+
+		it("does not rewrite file if replacements fail", func() {
+			path := filepath.Join(t.TempDir(), "buildpack.toml")
+			Expect(os.WriteFile(path, document, 0644)).To(Succeed())
+
+			Expect(descriptor.Rewrite(path, func(descriptor.Values) (map[string]string, error) {
+				return nil, fmt.Errorf("test-error")
+			})).To(MatchError("test-error"))
+
+			b, err := os.ReadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(b).To(Equal(document))
+		})
+	}, spec.Report(report.Terminal{}))
+}
@@ -0,0 +1,268 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/pelletier/go-toml/v2"
+
+	"github.com/buildpacks/github-actions/buildpack/internal/descriptor"
+	"github.com/buildpacks/github-actions/internal/toolkit"
+)
+
+// LatestAPI is the latest Buildpack API version known to the linter.
+const LatestAPI = "0.11"
+
+var (
+	validAPI = regexp.MustCompile(`^(\d+)\.(\d+)$`)
+	validID  = regexp.MustCompile(`^[a-zA-Z0-9./-]+$`)
+
+	reservedIDs = map[string]bool{"app": true, "config": true, "sbom": true}
+	sbomFormats = map[string]bool{
+		"application/spdx+json":          true,
+		"application/vnd.cyclonedx+json": true,
+		"application/vnd.syft+json":      true,
+	}
+)
+
+type Problem struct {
+	Key     string
+	Message string
+	Warning bool
+}
+
+func Lint(tk toolkit.Toolkit) error {
+	c, err := parseConfig(tk)
+	if err != nil {
+		return err
+	}
+
+	b, err := os.ReadFile(c.Path)
+	if err != nil {
+		return toolkit.FailedErrorf("unable to read %s", c.Path)
+	}
+
+	d, err := descriptor.Read(c.Path, c.Kind)
+	if err != nil {
+		m := toolkit.MessageContext{File: c.Path, Line: "1", Message: err.Error()}
+
+		var decode *toml.DecodeError
+		if errors.As(err, &decode) {
+			row, column := decode.Position()
+			m.Line, m.Column, m.Message = strconv.Itoa(row), strconv.Itoa(column), decode.Error()
+		}
+
+		tk.Errorc(m)
+		return toolkit.FailedErrorf("%s is not a valid descriptor", c.Path)
+	}
+
+	lines, err := descriptor.ParseLines(b)
+	if err != nil {
+		return toolkit.FailedErrorf("unable to parse %s\n%w", c.Path, err)
+	}
+
+	var errs, warnings int
+	for _, p := range Check(d) {
+		m := toolkit.MessageContext{File: c.Path, Line: strconv.Itoa(lines.Line(p.Key)), Message: p.Message}
+
+		if p.Warning {
+			tk.Warningc(m)
+			warnings++
+		} else {
+			tk.Errorc(m)
+			errs++
+		}
+	}
+
+	tk.SetOutput("errors", strconv.Itoa(errs))
+	tk.SetOutput("warnings", strconv.Itoa(warnings))
+
+	if errs > 0 || (c.WarningsAsErrors && warnings > 0) {
+		return toolkit.FailedErrorf("%s has %d errors and %d warnings", c.Path, errs, warnings)
+	}
+
+	fmt.Printf("Linted %s: %d warnings\n", c.Path, warnings)
+	return nil
+}
+
+// Check returns the problems of a descriptor against the Buildpack API version it declares.
+func Check(d descriptor.Descriptor) []Problem {
+	var problems []Problem
+
+	problem := func(key string, format string, a ...interface{}) {
+		problems = append(problems, Problem{Key: key, Message: fmt.Sprintf(format, a...)})
+	}
+	warning := func(key string, format string, a ...interface{}) {
+		problems = append(problems, Problem{Key: key, Message: fmt.Sprintf(format, a...), Warning: true})
+	}
+
+	api, ok := parseAPI(d.API)
+	if d.API == "" {
+		problem("api", "api must be set")
+	} else if !ok {
+		problem("api", "api %s must be in {major}.{minor} form", d.API)
+	} else if latest, _ := parseAPI(LatestAPI); compare(api, latest) > 0 {
+		warning("api", "api %s is newer than %s, the latest version known to the linter", d.API, LatestAPI)
+	}
+	atLeast := func(version string) bool {
+		v, _ := parseAPI(version)
+		return !ok || compare(api, v) >= 0
+	}
+
+	table := d.Kind
+	info := d.Info()
+
+	if info.ID == "" {
+		problem(table+".id", "%s.id must be set", table)
+	} else if !validID.MatchString(info.ID) {
+		problem(table+".id", "%s.id %s may only contain letters, numbers, '.', '/', and '-'", table, info.ID)
+	} else if reservedIDs[info.ID] {
+		problem(table+".id", "%s.id %s is reserved", table, info.ID)
+	}
+
+	if info.Name == "" {
+		problem(table+".name", "%s.name must be set", table)
+	}
+
+	if info.Version == "" {
+		problem(table+".version", "%s.version must be set", table)
+	} else if _, err := semver.StrictNewVersion(info.Version); err != nil {
+		problem(table+".version", "%s.version %s must be a semantic version", table, info.Version)
+	}
+
+	for i, l := range info.Licenses {
+		key := fmt.Sprintf("%s.licenses[%d]", table, i)
+		if l.Type == "" && l.URI == "" {
+			problem(key, "%s.licenses must have a type or a uri", table)
+		} else if l.Type != "" && !descriptor.SPDXLicense(l.Type) && !strings.HasPrefix(l.Type, "LicenseRef-") {
+			problem(key, "%s.licenses type %s is not a known SPDX license identifier", table, l.Type)
+		}
+	}
+
+	if len(info.SBOMFormats) > 0 && !atLeast("0.7") {
+		problem(table+".sbom-formats", "%s.sbom-formats requires api 0.7 or later", table)
+	}
+	for _, f := range info.SBOMFormats {
+		if !sbomFormats[f] {
+			problem(table+".sbom-formats", "%s.sbom-formats %s is not a known SBOM media type", table, f)
+		}
+	}
+
+	if d.Kind == descriptor.KindExtension {
+		if !atLeast("0.9") {
+			problem("api", "extensions require api 0.9 or later")
+		}
+		if len(d.Order) > 0 {
+			problem("order", "extensions must not have an order")
+		}
+		if len(d.Stacks) > 0 {
+			problem("stacks", "extensions must not have stacks")
+		}
+	}
+
+	if len(d.Stacks) > 0 && len(d.Targets) > 0 {
+		problem("targets", "stacks and targets are mutually exclusive")
+	}
+
+	if len(d.Targets) > 0 && !atLeast("0.10") {
+		problem("targets", "targets requires api 0.10 or later, use stacks")
+	}
+
+	if len(d.Stacks) > 0 && len(d.Targets) == 0 && atLeast("0.10") {
+		warning("stacks", "stacks are deprecated in api 0.10 and later, use targets")
+	}
+
+	for i, s := range d.Stacks {
+		if s.ID == "" {
+			problem(fmt.Sprintf("stacks[%d]", i), "stacks must have an id")
+		}
+	}
+
+	for i, t := range d.Targets {
+		if t.OS == "" {
+			problem(fmt.Sprintf("targets[%d]", i), "targets must have an os")
+		}
+	}
+
+	if d.Kind == descriptor.KindBuildpack && len(d.Order) == 0 && len(d.Stacks) == 0 && !atLeast("0.10") {
+		problem("buildpack", "buildpacks without an order must have stacks before api 0.10")
+	}
+
+	for i, o := range d.Order {
+		if len(o.Groups) == 0 {
+			problem(fmt.Sprintf("order[%d]", i), "order groups must not be empty")
+		}
+
+		for j, g := range o.Groups {
+			if g.ID == "" {
+				problem(fmt.Sprintf("order[%d].group[%d]", i, j), "order group entries must have an id")
+			}
+		}
+	}
+
+	return problems
+}
+
+func parseAPI(s string) ([2]int, bool) {
+	g := validAPI.FindStringSubmatch(s)
+	if g == nil {
+		return [2]int{}, false
+	}
+
+	major, _ := strconv.Atoi(g[1])
+	minor, _ := strconv.Atoi(g[2])
+	return [2]int{major, minor}, true
+}
+
+func compare(a [2]int, b [2]int) int {
+	if a[0] != b[0] {
+		return a[0] - b[0]
+	}
+
+	return a[1] - b[1]
+}
+
+type config struct {
+	descriptor.Config
+
+	WarningsAsErrors bool
+}
+
+func parseConfig(tk toolkit.Toolkit) (config, error) {
+	var c config
+
+	d, err := descriptor.ParseConfig(tk)
+	if err != nil {
+		return config{}, err
+	}
+	c.Config = d
+
+	if s, ok := tk.GetInput("warnings-as-errors"); ok {
+		if t, err := strconv.ParseBool(s); err == nil {
+			c.WarningsAsErrors = t
+		}
+	}
+
+	return c, nil
+}
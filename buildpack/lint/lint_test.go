@@ -0,0 +1,177 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package lint_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/buildpacks/github-actions/buildpack/lint"
+	"github.com/buildpacks/github-actions/internal/toolkit"
+)
+
+func TestLint(t *testing.T) {
+	spec.Run(t, "lint", func(t *testing.T, context spec.G, it spec.S) {
+		var (
+			Expect = NewWithT(t).Expect
+
+			path string
+			tk   = &toolkit.MockToolkit{}
+		)
+
+		write := func(content string) {
+			Expect(os.WriteFile(path, []byte(content), 0644)).To(Succeed())
+		}
+
+		errorAt := func(line string, message string) {
+			tk.AssertCalled(t, "Errorc", toolkit.MessageContext{File: path, Line: line, Message: message})
+		}
+
+		it.Before(func() {
+			path = filepath.Join(t.TempDir(), "buildpack.toml")
+
+			tk.On("GetInput", "kind").Return("", false)
+			tk.On("GetInput", "path").Return(path, true)
+			tk.On("GetInput", "warnings-as-errors").Return("", false)
+			tk.On("Errorc", mock.Anything)
+			tk.On("Warningc", mock.Anything)
+			tk.On("SetOutput", mock.Anything, mock.Anything)
+		})
+
+		it("passes valid buildpack", func() {
+			write(`api = "0.10"
+
+[buildpack]
+id       = "test-namespace/test-name"
+name     = "Test Name"
+version  = "1.2.3"
+licenses = [{ type = "Apache-2.0" }]
+
+[[targets]]
+os   = "linux"
+arch = "amd64"
+`)
+
+			Expect(lint.Lint(tk)).To(Succeed())
+			tk.AssertNotCalled(t, "Errorc", mock.Anything)
+			tk.AssertCalled(t, "SetOutput", "errors", "0")
+		})
+
+		it("passes valid meta-buildpack", func() {
+			write(`api = "0.8"
+
+[buildpack]
+id      = "test-namespace/test-meta"
+name    = "Test Meta"
+version = "1.2.3"
+
+[[order]]
+[[order.group]]
+id      = "test-namespace/test-name"
+version = "1.2.3"
+`)
+
+			Expect(lint.Lint(tk)).To(Succeed())
+			tk.AssertNotCalled(t, "Errorc", mock.Anything)
+		})
+
+		it("reports problems with their line", func() {
+			write(`api = "0.6"
+
+[buildpack]
+id           = "test namespace"
+version      = "1.2"
+sbom-formats = ["application/spdx+json"]
+
+[[buildpack.licenses]]
+type = "Not-A-License"
+
+[[stacks]]
+id = "io.buildpacks.stacks.jammy"
+
+[[targets]]
+arch = "amd64"
+
+[[order]]
+`)
+
+			Expect(lint.Lint(tk)).To(MatchError("::error ::" + path + " has 9 errors and 0 warnings"))
+
+			errorAt("4", "buildpack.id test namespace may only contain letters, numbers, '.', '/', and '-'")
+			errorAt("3", "buildpack.name must be set")
+			errorAt("5", "buildpack.version 1.2 must be a semantic version")
+			errorAt("8", "buildpack.licenses type Not-A-License is not a known SPDX license identifier")
+			errorAt("6", "buildpack.sbom-formats requires api 0.7 or later")
+			errorAt("14", "stacks and targets are mutually exclusive")
+			errorAt("14", "targets requires api 0.10 or later, use stacks")
+			errorAt("14", "targets must have an os")
+			errorAt("17", "order groups must not be empty")
+		})
+
+		it("warns about deprecated stacks", func() {
+			write(`api = "0.10"
+
+[buildpack]
+id      = "test-namespace/test-name"
+name    = "Test Name"
+version = "1.2.3"
+
+[[stacks]]
+id = "*"
+`)
+
+			Expect(lint.Lint(tk)).To(Succeed())
+			tk.AssertCalled(t, "Warningc", toolkit.MessageContext{File: path, Line: "8", Message: "stacks are deprecated in api 0.10 and later, use targets"})
+		})
+
+		it("reports extension problems", func() {
+			write(`api = "0.8"
+
+[extension]
+id      = "test-namespace/test-extension"
+name    = "Test Extension"
+version = "1.2.3"
+
+[[order]]
+[[order.group]]
+id = "test-namespace/test-name"
+`)
+
+			Expect(lint.Lint(tk)).To(HaveOccurred())
+			errorAt("1", "extensions require api 0.9 or later")
+			errorAt("8", "extensions must not have an order")
+		})
+
+		it("reports invalid TOML", func() {
+			write(`api = "0.10"
+
+[buildpack
+`)
+
+			Expect(lint.Lint(tk)).To(MatchError("::error ::" + path + " is not a valid descriptor"))
+			tk.AssertCalled(t, "Errorc", mock.MatchedBy(func(m toolkit.MessageContext) bool {
+				return m.File == path && m.Line == "3"
+			}))
+		})
+	}, spec.Report(report.Terminal{}))
+}
@@ -0,0 +1,308 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dependency
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/buildpacks/github-actions/buildpack/internal/descriptor"
+	"github.com/buildpacks/github-actions/internal/toolkit"
+)
+
+type change struct {
+	ID              string `json:"id"`
+	PreviousVersion string `json:"previous-version"`
+	Version         string `json:"version"`
+	PreviousURI     string `json:"previous-uri"`
+	URI             string `json:"uri"`
+	PreviousSHA256  string `json:"previous-sha256"`
+	SHA256          string `json:"sha256"`
+}
+
+func UpdateDependency(tk toolkit.Toolkit) error {
+	c, err := parseConfig(tk)
+	if err != nil {
+		return err
+	}
+
+	if c.File != "" {
+		if c.SHA256, err = digest(c.File); err != nil {
+			return err
+		}
+	}
+
+	d, err := descriptor.Read(c.Path, c.Kind)
+	if err != nil {
+		return toolkit.FailedError(err)
+	}
+
+	var changes []change
+	if err := descriptor.Rewrite(c.Path, func(values descriptor.Values) (map[string]string, error) {
+		u, replacements, err := update(d, values, c)
+		changes = u
+		return replacements, err
+	}); err != nil {
+		return err
+	}
+
+	var summary []string
+	for _, c := range changes {
+		summary = append(summary, fmt.Sprintf("Updated %s from %s to %s", c.ID, c.PreviousVersion, c.Version))
+	}
+	fmt.Println(strings.Join(summary, "\n"))
+
+	j, err := json.Marshal(changes)
+	if err != nil {
+		return toolkit.FailedErrorf("unable to marshal changes\n%w", err)
+	}
+
+	tk.SetOutput("changes", string(j))
+	tk.SetOutput("summary", strings.Join(summary, "\n"))
+
+	return nil
+}
+
+// update returns the changes to the dependencies of a descriptor matching the config and the replacements of their
+// values.
+func update(d descriptor.Descriptor, values descriptor.Values, c config) ([]change, map[string]string, error) {
+	var (
+		changes      = []change{}
+		matched      []int
+		replacements = map[string]string{}
+	)
+
+	for i, dep := range d.Metadata.Dependencies {
+		if dep.ID == c.ID && matches(c.PreviousVersion, dep.Version) {
+			matched = append(matched, i)
+		}
+	}
+
+	if len(matched) > 1 && c.PreviousVersion == "" {
+		return nil, nil, toolkit.FailedErrorf("%d dependencies %s in %s, previous-version must be set to select which to update", len(matched), c.ID, c.Path)
+	}
+
+	for _, i := range matched {
+		dep := d.Metadata.Dependencies[i]
+
+		path := fmt.Sprintf("metadata.dependencies[%d]", i)
+		if _, ok := values[path+".version"]; !ok {
+			return nil, nil, toolkit.FailedErrorf("dependency %s@%s in %s must be defined in a [[metadata.dependencies]] table to be updated", dep.ID, dep.Version, c.Path)
+		}
+
+		replacements[path+".version"] = c.Version
+
+		if _, ok := values[path+".uri"]; ok {
+			replacements[path+".uri"] = c.URI
+		}
+
+		if _, ok := values[path+".sha256"]; ok {
+			replacements[path+".sha256"] = c.SHA256
+		}
+
+		if _, ok := values[path+".checksum"]; ok {
+			replacements[path+".checksum"] = fmt.Sprintf("sha256:%s", c.SHA256)
+		}
+
+		if v, ok := values[path+".purl"]; ok {
+			replacements[path+".purl"] = purlVersion(v.Value, c.Version)
+		}
+
+		for j := range dep.CPEs {
+			if v, ok := values[fmt.Sprintf("%s.cpes[%d]", path, j)]; ok {
+				replacements[fmt.Sprintf("%s.cpes[%d]", path, j)] = cpeVersion(v.Value, c.Version)
+			}
+		}
+
+		changes = append(changes, change{
+			ID:              dep.ID,
+			PreviousVersion: dep.Version,
+			Version:         c.Version,
+			PreviousURI:     dep.URI,
+			URI:             c.URI,
+			PreviousSHA256:  dep.SHA256,
+			SHA256:          c.SHA256,
+		})
+	}
+
+	if len(changes) == 0 {
+		if c.PreviousVersion != "" {
+			return nil, nil, toolkit.FailedErrorf("no dependency %s with a version matching %s in %s", c.ID, c.PreviousVersion, c.Path)
+		}
+		return nil, nil, toolkit.FailedErrorf("no dependency %s in %s", c.ID, c.Path)
+	}
+
+	return changes, replacements, nil
+}
+
+// purlVersion returns a package URL with its version, between the last @ and any qualifiers or subpath, replaced.
+func purlVersion(purl string, version string) string {
+	end := len(purl)
+	if i := strings.IndexAny(purl, "?#"); i >= 0 {
+		end = i
+	}
+
+	i := strings.LastIndex(purl[:end], "@")
+	if i < 0 {
+		return purl
+	}
+
+	return purl[:i+1] + strings.ReplaceAll(url.PathEscape(version), "+", "%2B") + purl[end:]
+}
+
+// cpeVersion returns a CPE 2.3 formatted string or a CPE 2.2 URI with its version field replaced.  Versions that are
+// logical values such as * or - are left as is.
+func cpeVersion(cpe string, version string) string {
+	field, escaped := 5, cpeEscape(version)
+	if strings.HasPrefix(cpe, "cpe:/") {
+		field, escaped = 4, url.PathEscape(version)
+	}
+
+	var (
+		start = -1
+		n     = 0
+	)
+	for i := 0; i <= len(cpe); i++ {
+		if i < len(cpe) && cpe[i] == '\\' {
+			i++
+			continue
+		}
+
+		if i == len(cpe) || cpe[i] == ':' {
+			if n == field {
+				if v := cpe[start+1 : i]; v == "*" || v == "-" || v == "" {
+					return cpe
+				}
+				return cpe[:start+1] + escaped + cpe[i:]
+			}
+			start = i
+			n++
+		}
+	}
+
+	return cpe
+}
+
+func cpeEscape(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '.' || r == '_' || r == '-') {
+			sb.WriteByte('\\')
+		}
+		sb.WriteRune(r)
+	}
+
+	return sb.String()
+}
+
+func matches(constraint string, version string) bool {
+	if constraint == "" {
+		return true
+	}
+
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return false
+	}
+
+	v, err := semver.NewVersion(version)
+	if err != nil {
+		return false
+	}
+
+	return c.Check(v)
+}
+
+func digest(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", toolkit.FailedErrorf("unable to open %s\n%w", path, err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", toolkit.FailedErrorf("unable to read %s\n%w", path, err)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+type config struct {
+	descriptor.Config
+
+	ID              string
+	Version         string
+	PreviousVersion string
+	URI             string
+	SHA256          string
+	File            string
+}
+
+func parseConfig(tk toolkit.Toolkit) (config, error) {
+	var (
+		c  config
+		ok bool
+	)
+
+	d, err := descriptor.ParseConfig(tk)
+	if err != nil {
+		return config{}, err
+	}
+	c.Config = d
+
+	if c.ID, ok = tk.GetInput("id"); !ok {
+		return config{}, toolkit.FailedError("id must be set")
+	}
+
+	if c.Version, ok = tk.GetInput("version"); !ok {
+		return config{}, toolkit.FailedError("version must be set")
+	}
+
+	if c.URI, ok = tk.GetInput("uri"); !ok {
+		return config{}, toolkit.FailedError("uri must be set")
+	}
+
+	if s, ok := tk.GetInput("previous-version"); ok && s != "" {
+		if _, err := semver.NewConstraint(s); err != nil {
+			return config{}, toolkit.FailedErrorf("invalid previous-version %s, must be a semantic version constraint", s)
+		}
+		c.PreviousVersion = s
+	}
+
+	if s, ok := tk.GetInput("sha256"); ok {
+		c.SHA256 = s
+	}
+
+	if s, ok := tk.GetInput("file"); ok {
+		c.File = s
+	}
+
+	if (c.SHA256 == "") == (c.File == "") {
+		return config{}, toolkit.FailedError("exactly one of sha256 or file must be set")
+	}
+
+	return c, nil
+}
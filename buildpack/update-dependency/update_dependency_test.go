@@ -0,0 +1,214 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package dependency_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/stretchr/testify/mock"
+
+	dependency "github.com/buildpacks/github-actions/buildpack/update-dependency"
+	"github.com/buildpacks/github-actions/internal/toolkit"
+)
+
+const document = `api = "0.8"
+
+[buildpack]
+id      = "test-namespace/test-name"
+version = "1.2.3"
+
+[[stacks]]
+id = "*"
+
+# JDK 11
+[[metadata.dependencies]]
+id      = "test-jdk"
+version = "11.0.1"   # keep this comment
+uri     = "https://localhost/jdk-11.0.1.tgz"
+sha256  = "test-sha256-11"
+stacks  = ["*"]
+cpes    = ["cpe:2.3:a:test:jdk:11.0.1:update11.0.1:*:*:*:*:*:*", "cpe:/a:test:jdk:11.0.1"]
+purl    = "pkg:generic/jdk@11.0.1?download_url=jdk-11.0.1.tgz#lib/11.0.1"
+
+# JDK 17
+[[metadata.dependencies]]
+id      = "test-jdk"
+version = "17.0.1"
+uri     = "https://localhost/jdk-17.0.1.tgz"
+sha256  = "test-sha256-17"
+stacks  = ["*"]
+`
+
+func TestUpdateDependency(t *testing.T) {
+	spec.Run(t, "update-dependency", func(t *testing.T, context spec.G, it spec.S) {
+		var (
+			Expect = NewWithT(t).Expect
+
+			path string
+			tk   = &toolkit.MockToolkit{}
+		)
+
+		read := func() string {
+			b, err := os.ReadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			return string(b)
+		}
+
+		it.Before(func() {
+			path = filepath.Join(t.TempDir(), "buildpack.toml")
+			Expect(os.WriteFile(path, []byte(document), 0644)).To(Succeed())
+
+			tk.On("GetInput", "kind").Return("", false)
+			tk.On("GetInput", "path").Return(path, true)
+			tk.On("GetInput", "id").Return("test-jdk", true)
+			tk.On("GetInput", "uri").Return("https://localhost/jdk-11.0.2.tgz", true)
+			tk.On("SetOutput", mock.Anything, mock.Anything)
+		})
+
+		context("sha256", func() {
+			it.Before(func() {
+				tk.On("GetInput", "version").Return("11.0.2", true)
+				tk.On("GetInput", "sha256").Return("test-sha256-new", true)
+				tk.On("GetInput", "file").Return("", false)
+			})
+
+			it("updates matching dependencies in place", func() {
+				tk.On("GetInput", "previous-version").Return("11.*", true)
+
+				Expect(dependency.UpdateDependency(tk)).To(Succeed())
+
+				Expect(read()).To(Equal(`api = "0.8"
+
+[buildpack]
+id      = "test-namespace/test-name"
+version = "1.2.3"
+
+[[stacks]]
+id = "*"
+
+# JDK 11
+[[metadata.dependencies]]
+id      = "test-jdk"
+version = "11.0.2"   # keep this comment
+uri     = "https://localhost/jdk-11.0.2.tgz"
+sha256  = "test-sha256-new"
+stacks  = ["*"]
+cpes    = ["cpe:2.3:a:test:jdk:11.0.2:update11.0.1:*:*:*:*:*:*", "cpe:/a:test:jdk:11.0.2"]
+purl    = "pkg:generic/jdk@11.0.2?download_url=jdk-11.0.1.tgz#lib/11.0.1"
+
+# JDK 17
+[[metadata.dependencies]]
+id      = "test-jdk"
+version = "17.0.1"
+uri     = "https://localhost/jdk-17.0.1.tgz"
+sha256  = "test-sha256-17"
+stacks  = ["*"]
+`))
+
+				tk.AssertCalled(t, "SetOutput", "summary", "Updated test-jdk from 11.0.1 to 11.0.2")
+				tk.AssertCalled(t, "SetOutput", "changes", `[{"id":"test-jdk","previous-version":"11.0.1","version":"11.0.2","previous-uri":"https://localhost/jdk-11.0.1.tgz","uri":"https://localhost/jdk-11.0.2.tgz","previous-sha256":"test-sha256-11","sha256":"test-sha256-new"}]`)
+			})
+
+			it("fails if several dependencies match without previous-version", func() {
+				tk.On("GetInput", "previous-version").Return("", false)
+
+				Expect(dependency.UpdateDependency(tk)).
+					To(MatchError("::error ::2 dependencies test-jdk in " + path + ", previous-version must be set to select which to update"))
+				Expect(read()).To(Equal(document))
+			})
+
+			it("treats empty previous-version as not set", func() {
+				tk.On("GetInput", "previous-version").Return("", true)
+
+				Expect(dependency.UpdateDependency(tk)).
+					To(MatchError("::error ::2 dependencies test-jdk in " + path + ", previous-version must be set to select which to update"))
+			})
+
+			it("fails if no dependency matches", func() {
+				tk.On("GetInput", "previous-version").Return("8.*", true)
+
+				Expect(dependency.UpdateDependency(tk)).To(MatchError("::error ::no dependency test-jdk with a version matching 8.* in " + path))
+				Expect(read()).To(Equal(document))
+			})
+		})
+
+		context("file", func() {
+			it.Before(func() {
+				file := filepath.Join(t.TempDir(), "jdk.tgz")
+				Expect(os.WriteFile(file, []byte("test-content"), 0644)).To(Succeed())
+
+				tk.On("GetInput", "version").Return("17.0.2", true)
+				tk.On("GetInput", "previous-version").Return("17.*", true)
+				tk.On("GetInput", "sha256").Return("", false)
+				tk.On("GetInput", "file").Return(file, true)
+			})
+
+			it("computes sha256 from file", func() {
+				Expect(dependency.UpdateDependency(tk)).To(Succeed())
+
+				Expect(read()).To(ContainSubstring(`sha256  = "0a3666a0710c08aa6d0de92ce72beeb5b93124cce1bf3701c9d6cdeb543cb73e"`))
+				Expect(read()).To(ContainSubstring(`sha256  = "test-sha256-11"`))
+			})
+		})
+
+		it("replaces only the version of purl and cpes", func() {
+			Expect(os.WriteFile(path, []byte(`[buildpack]
+id      = "test-namespace/test-name"
+version = "1.2.3"
+
+[[metadata.dependencies]]
+id      = "test-jdk"
+version = "1.2"
+uri     = "https://localhost/lib-1.2.tgz"
+sha256  = "test-sha256"
+cpes    = ["cpe:2.3:a:test:lib1.2:1.2:1.2.3:*:*:*:*:*:*"]
+purl    = "pkg:generic/lib1.2@1.2?tag=1.2.3"
+`), 0644)).To(Succeed())
+
+			tk.On("GetInput", "version").Return("1.3+4", true)
+			tk.On("GetInput", "previous-version").Return("", false)
+			tk.On("GetInput", "sha256").Return("test-sha256-new", true)
+			tk.On("GetInput", "file").Return("", false)
+
+			Expect(dependency.UpdateDependency(tk)).To(Succeed())
+
+			Expect(read()).To(ContainSubstring(`cpes    = ["cpe:2.3:a:test:lib1.2:1.3\\+4:1.2.3:*:*:*:*:*:*"]`))
+			Expect(read()).To(ContainSubstring(`purl    = "pkg:generic/lib1.2@1.3%2B4?tag=1.2.3"`))
+		})
+
+		it("fails without sha256 or file", func() {
+			tk.On("GetInput", "version").Return("11.0.2", true)
+			tk.On("GetInput", "previous-version").Return("", false)
+			tk.On("GetInput", "sha256").Return("", false)
+			tk.On("GetInput", "file").Return("", false)
+
+			Expect(dependency.UpdateDependency(tk)).To(MatchError("::error ::exactly one of sha256 or file must be set"))
+		})
+
+		it("fails with invalid previous-version", func() {
+			tk.On("GetInput", "version").Return("11.0.2", true)
+			tk.On("GetInput", "previous-version").Return("eleven", true)
+
+			Expect(dependency.UpdateDependency(tk)).To(MatchError("::error ::invalid previous-version eleven, must be a semantic version constraint"))
+		})
+	}, spec.Report(report.Terminal{}))
+}
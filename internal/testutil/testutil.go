@@ -0,0 +1,95 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package testutil
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	. "github.com/onsi/gomega"
+
+	verify "github.com/buildpacks/github-actions/buildpackage/verify-metadata"
+	"github.com/buildpacks/github-actions/internal/toolkit"
+)
+
+// Output returns the last value a mock toolkit set for an output, or "" if the output was not set.
+func Output(tk *toolkit.MockToolkit, name string) string {
+	var s string
+	for _, c := range tk.Calls {
+		if c.Method == "SetOutput" && c.Arguments[0] == name {
+			s = c.Arguments[1].(string)
+		}
+	}
+
+	return s
+}
+
+// Registry starts an in-memory registry that is closed when the test ends and returns its host.
+func Registry(t *testing.T) string {
+	server := httptest.NewServer(registry.New(registry.Logger(log.New(io.Discard, "", 0))))
+	t.Cleanup(server.Close)
+
+	return strings.TrimPrefix(server.URL, "http://")
+}
+
+// Push writes a random image with labels to the repository and tag of a registry host and returns the reference of
+// the tag and the digest of the image.
+func Push(t *testing.T, host string, repository string, tag string, labels map[string]string) (string, string) {
+	Expect := NewWithT(t).ExpectWithOffset
+
+	image, err := random.Image(1024, 1)
+	Expect(1, err).NotTo(HaveOccurred())
+
+	image, err = mutate.Config(image, v1.Config{Labels: labels})
+	Expect(1, err).NotTo(HaveOccurred())
+
+	ref, err := name.ParseReference(fmt.Sprintf("%s/%s:%s", host, repository, tag))
+	Expect(1, err).NotTo(HaveOccurred())
+	Expect(1, remote.Write(ref, image)).To(Succeed())
+
+	digest, err := image.Digest()
+	Expect(1, err).NotTo(HaveOccurred())
+
+	return ref.Name(), digest.String()
+}
+
+// BuildpackageLabels returns the labels of a buildpackage with an id and version.
+func BuildpackageLabels(id string, version string) map[string]string {
+	return map[string]string{
+		verify.MetadataLabel: fmt.Sprintf(`{ "id": "%s", "version": "%s" }`, id, version),
+	}
+}
+
+// WriteFile writes content to a file, creating its parent directories.
+func WriteFile(t *testing.T, path string, content string) {
+	Expect := NewWithT(t).ExpectWithOffset
+
+	Expect(1, os.MkdirAll(filepath.Dir(path), 0755)).To(Succeed())
+	Expect(1, os.WriteFile(path, []byte(content), 0644)).To(Succeed())
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package toolkit
+
+import (
+	"strings"
+)
+
+// ScopedToolkit allows one action to run another.  Inputs take precedence over the inputs of the wrapped Toolkit and
+// outputs are recorded in Outputs instead of being set on the wrapped Toolkit.
+type ScopedToolkit struct {
+	Toolkit
+	Inputs  map[string]string
+	Outputs map[string]string
+}
+
+func (s *ScopedToolkit) GetInput(name string) (string, bool) {
+	if v, ok := s.Inputs[name]; ok {
+		return v, true
+	}
+
+	return s.Toolkit.GetInput(name)
+}
+
+func (s *ScopedToolkit) GetInputList(name string) ([]string, bool) {
+	if v, ok := s.Inputs[name]; ok {
+		return strings.Split(v, ","), true
+	}
+
+	return s.Toolkit.GetInputList(name)
+}
+
+func (s *ScopedToolkit) SetOutput(name string, value string) {
+	if s.Outputs == nil {
+		s.Outputs = make(map[string]string)
+	}
+
+	s.Outputs[name] = value
+}
+
+// ErrorMessage returns the message of an error created by FailedError, FailedErrorc or FailedErrorf without its
+// workflow command prefix.
+func ErrorMessage(err error) string {
+	s := err.Error()
+
+	if strings.HasPrefix(s, "::error") {
+		if i := strings.Index(s[2:], "::"); i >= 0 {
+			s = s[i+4:]
+		}
+	}
+
+	return strings.ReplaceAll(s, "%0A", "\n")
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package toolkit_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/github-actions/internal/toolkit"
+)
+
+func TestScopedToolkit(t *testing.T) {
+	spec.Run(t, "ScopedToolkit", func(t *testing.T, context spec.G, it spec.S) {
+		var (
+			Expect = NewWithT(t).Expect
+
+			tk = &toolkit.MockToolkit{}
+			s  = &toolkit.ScopedToolkit{Toolkit: tk, Inputs: map[string]string{"test-1": "test-value-1,test-value-2"}}
+		)
+
+		it("prefers scoped input", func() {
+			v, ok := s.GetInput("test-1")
+			Expect(ok).To(BeTrue())
+			Expect(v).To(Equal("test-value-1,test-value-2"))
+
+			l, ok := s.GetInputList("test-1")
+			Expect(ok).To(BeTrue())
+			Expect(l).To(Equal([]string{"test-value-1", "test-value-2"}))
+		})
+
+		it("falls back to wrapped input", func() {
+			tk.On("GetInput", "test-2").Return("test-value", true)
+
+			v, ok := s.GetInput("test-2")
+			Expect(ok).To(BeTrue())
+			Expect(v).To(Equal("test-value"))
+		})
+
+		it("records output", func() {
+			s.SetOutput("test-name", "test-value")
+
+			Expect(s.Outputs).To(Equal(map[string]string{"test-name": "test-value"}))
+		})
+
+		it("returns error message", func() {
+			Expect(toolkit.ErrorMessage(toolkit.FailedErrorf("%s\n%s", "test-message-1", "test-message-2"))).
+				To(Equal("test-message-1\ntest-message-2"))
+			Expect(toolkit.ErrorMessage(toolkit.FailedErrorc(toolkit.MessageContext{File: "test-file", Message: "test-message"}))).
+				To(Equal("test-message"))
+		})
+
+	}, spec.Report(report.Terminal{}))
+}
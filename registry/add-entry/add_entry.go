@@ -18,23 +18,39 @@ package entry
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/google/go-github/v89/github"
 	"gopkg.in/retry.v1"
 
 	"github.com/buildpacks/github-actions/internal/toolkit"
+	"github.com/buildpacks/github-actions/registry/internal/commit"
 	"github.com/buildpacks/github-actions/registry/internal/index"
 	"github.com/buildpacks/github-actions/registry/internal/services"
 )
 
-func AddEntry(tk toolkit.Toolkit, repositories services.RepositoriesService, strategy retry.Strategy) error {
+func AddEntry(tk toolkit.Toolkit, git services.GitService, pulls services.PullRequestsService, repositories services.RepositoriesService, strategy retry.Strategy) error {
 	c, err := parseConfig(tk)
 	if err != nil {
 		return err
 	}
 
+	policy, err := index.LoadAddressPolicy(tk, repositories, c.Owner, c.Repository)
+	if err != nil {
+		return err
+	}
+
+	if err := policy.Check(c.Namespace, c.Address); err != nil {
+		return toolkit.FailedError(err)
+	}
+
+	committer := commit.Committer{Config: c.Commit, Git: git, PullRequests: pulls, Repositories: repositories}
+
 	file := index.Path(c.Namespace, c.Name)
 
 	for a := retry.Start(strategy, nil); a.Next(); {
@@ -56,10 +72,16 @@ func AddEntry(tk toolkit.Toolkit, repositories services.RepositoriesService, str
 			return toolkit.FailedErrorf("unable to unmarshal entries\n%w", err)
 		}
 
-		if contains(entries, c.Namespace, c.Version) {
+		if e, ok := find(entries, c.Namespace, c.Version); ok && e.Yanked && c.Policy.NoYankedReuse {
+			return toolkit.FailedErrorf("version %s reuses yanked version %s of %s/%s", c.Version, e.Version, c.Namespace, c.Name)
+		} else if ok {
 			return toolkit.FailedErrorf("index %s already has namespace %s and version %s", c.Name, c.Namespace, c.Version)
 		}
 
+		if err := checkVersion(entries, c); err != nil {
+			return err
+		}
+
 		entries = append(entries, index.Entry{
 			Namespace: c.Namespace,
 			Name:      c.Name,
@@ -72,41 +94,86 @@ func AddEntry(tk toolkit.Toolkit, repositories services.RepositoriesService, str
 			return toolkit.FailedErrorf("unable to marshal entries\n%w", err)
 		}
 
-		if _, resp, err := repositories.CreateFile(context.Background(), c.Owner, c.Repository, file, &github.RepositoryContentFileOptions{
-			Author: &github.CommitAuthor{
-				Name:  github.Ptr("buildpacks-bot"),
-				Email: github.Ptr("cncf-buildpacks-maintainers@lists.cncf.io"),
-			},
-			Message: github.Ptr(fmt.Sprintf("ADD %s/%s@%s", c.Namespace, c.Name, c.Version)),
-			SHA:     content.SHA,
+		f := commit.File{
+			Path:    file,
 			Content: []byte(s),
-		}); resp != nil && resp.StatusCode == http.StatusConflict {
+			SHA:     content.SHA,
+		}
+		message := c.Commit.FormatMessage(commit.Values{
+			Namespace: c.Namespace,
+			Name:      c.Name,
+			Version:   c.Version,
+			URL:       c.URL,
+		})
+
+		if !c.PullRequest.Enabled {
+			if err := committer.Commit(context.Background(), c.Owner, c.Repository, f, message); errors.Is(err, commit.ErrConflict) {
+				tk.Warning("retrying index update after conflict")
+				continue
+			} else if err != nil {
+				return toolkit.FailedErrorf("unable to create index\n%w", err)
+			}
+
+			fmt.Printf("Added %s/%s@%s\n", c.Namespace, c.Name, c.Version)
+			return nil
+		}
+
+		body := fmt.Sprintf("Adds `%s/%s@%s` with address `%s` to the index.", c.Namespace, c.Name, c.Version, c.Address)
+		if c.URL != "" {
+			body = fmt.Sprintf("%s\n\nRequested in %s", body, c.URL)
+		}
+
+		pr, err := committer.Propose(context.Background(), c.Owner, c.Repository, f, message, commit.PullRequest{
+			Branch: fmt.Sprintf("add/%s/%s/%s", c.Namespace, c.Name, c.Version),
+			Title:  strings.SplitN(message, "\n", 2)[0],
+			Body:   body,
+		})
+		if errors.Is(err, commit.ErrConflict) {
 			tk.Warning("retrying index update after conflict")
 			continue
 		} else if err != nil {
-			return toolkit.FailedErrorf("unable to create index\n%w", err)
+			return toolkit.FailedErrorf("unable to propose index update\n%w", err)
+		}
+
+		fmt.Printf("Proposed %s/%s@%s in %s\n", c.Namespace, c.Name, c.Version, pr.GetHTMLURL())
+		tk.SetOutput("pull-request-url", pr.GetHTMLURL())
+		tk.SetOutput("pull-request-number", strconv.Itoa(pr.GetNumber()))
+
+		if c.PullRequest.Wait {
+			return commit.WaitForMerge(c.Owner, c.Repository, pr.GetNumber(), pr.GetHTMLURL(), tk, pulls, c.PullRequest.Strategy())
 		}
 
-		fmt.Printf("Added %s/%s@%s\n", c.Namespace, c.Name, c.Version)
 		return nil
 	}
 
 	return toolkit.FailedError("timed out")
 }
 
+type policy struct {
+	MonotonicVersion bool
+	PreReleaseFlag   bool
+	NoYankedReuse    bool
+}
+
 type config struct {
-	Owner      string
-	Repository string
-	Namespace  string
-	Name       string
-	Version    string
-	Address    string
+	Owner       string
+	Repository  string
+	Namespace   string
+	Name        string
+	Version     string
+	Address     string
+	URL         string
+	PreRelease  bool
+	Policy      policy
+	Commit      commit.Config
+	PullRequest commit.PullRequestConfig
 }
 
 func parseConfig(tk toolkit.Toolkit) (config, error) {
 	var (
-		c  config
-		ok bool
+		c   config
+		ok  bool
+		err error
 	)
 
 	c.Owner, ok = tk.GetInput("owner")
@@ -139,15 +206,101 @@ func parseConfig(tk toolkit.Toolkit) (config, error) {
 		return config{}, toolkit.FailedError("address must be set")
 	}
 
+	if s, ok := tk.GetInput("request-url"); ok {
+		c.URL = s
+	}
+
+	if s, ok := tk.GetInput("pre-release"); ok {
+		if t, err := strconv.ParseBool(s); err == nil {
+			c.PreRelease = t
+		}
+	}
+
+	if s, ok := tk.GetInput("require-monotonic-version"); ok {
+		if t, err := strconv.ParseBool(s); err == nil {
+			c.Policy.MonotonicVersion = t
+		}
+	}
+
+	if s, ok := tk.GetInput("require-pre-release-flag"); ok {
+		if t, err := strconv.ParseBool(s); err == nil {
+			c.Policy.PreReleaseFlag = t
+		}
+	}
+
+	if s, ok := tk.GetInput("forbid-yanked-version-reuse"); ok {
+		if t, err := strconv.ParseBool(s); err == nil {
+			c.Policy.NoYankedReuse = t
+		}
+	}
+
+	c.Commit, err = commit.ParseConfig(tk, "ADD {ns}/{name}@{version}")
+	if err != nil {
+		return config{}, err
+	}
+
+	c.PullRequest, err = commit.ParsePullRequestConfig(tk)
+	if err != nil {
+		return config{}, err
+	}
+
 	return c, nil
 }
 
-func contains(entries []index.Entry, namespace string, version string) bool {
+func find(entries []index.Entry, namespace string, version string) (index.Entry, bool) {
 	for _, e := range entries {
 		if e.Namespace == namespace && e.Version == version {
-			return true
+			return e, true
 		}
 	}
 
-	return false
+	return index.Entry{}, false
+}
+
+func checkVersion(entries []index.Entry, c config) error {
+	if !c.Policy.MonotonicVersion && !c.Policy.PreReleaseFlag && !c.Policy.NoYankedReuse {
+		return nil
+	}
+
+	v, err := semver.StrictNewVersion(c.Version)
+	if err != nil {
+		return toolkit.FailedErrorf("version %s must be a semantic version to apply version policies", c.Version)
+	}
+
+	if c.Policy.PreReleaseFlag {
+		if v.Prerelease() != "" && !c.PreRelease {
+			return toolkit.FailedErrorf("version %s is a pre-release and must be flagged with pre-release", c.Version)
+		} else if v.Prerelease() == "" && c.PreRelease {
+			return toolkit.FailedErrorf("version %s is flagged with pre-release but is not a pre-release", c.Version)
+		}
+	}
+
+	var highest *semver.Version
+	for _, e := range entries {
+		if e.Namespace != c.Namespace {
+			continue
+		}
+
+		w, err := semver.NewVersion(e.Version)
+		if err != nil {
+			continue
+		}
+
+		if e.Yanked {
+			if c.Policy.NoYankedReuse && v.Equal(w) {
+				return toolkit.FailedErrorf("version %s reuses yanked version %s of %s/%s", c.Version, e.Version, c.Namespace, c.Name)
+			}
+			continue
+		}
+
+		if w.Prerelease() == "" && (highest == nil || w.GreaterThan(highest)) {
+			highest = w
+		}
+	}
+
+	if c.Policy.MonotonicVersion && highest != nil && !v.GreaterThan(highest) {
+		return toolkit.FailedErrorf("version %s is not higher than %s, the highest release of %s/%s", c.Version, highest.Original(), c.Namespace, c.Name)
+	}
+
+	return nil
 }
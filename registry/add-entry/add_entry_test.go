@@ -42,6 +42,8 @@ func TestAddEntry(t *testing.T) {
 			Expect           = NewWithT(t).Expect
 			ExpectWithOffset = NewWithT(t).ExpectWithOffset
 
+			g     = &services.MockGitService{}
+			p     = &services.MockPullRequestsService{}
 			r     = &services.MockRepositoriesService{}
 			rOpts *github.RepositoryContentGetOptions
 			s     = retry.LimitCount(2, retry.Regular{Min: 2})
@@ -60,12 +62,28 @@ func TestAddEntry(t *testing.T) {
 			tk.On("GetInput", "repository").Return("test-repository", true)
 			tk.On("GetInput", "namespace").Return("test-namespace", true)
 			tk.On("GetInput", "name").Return("test-name", true)
-			tk.On("GetInput", "version").Return("test-version", true)
-			tk.On("GetInput", "address").Return("test-address", true)
+			tk.On("GetInput", "request-url").Return("", false)
+			tk.On("GetInput", "author-name").Return("", false)
+			tk.On("GetInput", "author-email").Return("", false)
+			tk.On("GetInput", "committer-name").Return("", false)
+			tk.On("GetInput", "committer-email").Return("", false)
+			tk.On("GetInput", "commit-message").Return("", false)
+			tk.On("GetInput", "signing-key").Return("", false)
+			tk.On("GetInput", "wait-for-merge").Return("", false)
+			tk.On("GetInput", "merge-timeout").Return("", false)
+			tk.On("GetInput", "address-policy-file").Return("", false)
 		})
 
 		context("index does not exist", func() {
 			it.Before(func() {
+				tk.On("GetInput", "address").Return("test-address", true)
+				tk.On("GetInput", "pull-request").Return("", false)
+				tk.On("GetInput", "version").Return("test-version", true)
+				tk.On("GetInput", "pre-release").Return("", false)
+				tk.On("GetInput", "require-monotonic-version").Return("", false)
+				tk.On("GetInput", "require-pre-release-flag").Return("", false)
+				tk.On("GetInput", "forbid-yanked-version-reuse").Return("", false)
+				tk.On("GetInput", "address-policy").Return("", false)
 				r.On("GetContents", mock.Anything, "test-owner", "test-repository", filepath.Join("te", "st", "test-namespace_test-name"), rOpts).
 					Return(nil, nil, &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, nil)
 			})
@@ -86,11 +104,22 @@ func TestAddEntry(t *testing.T) {
 				}).
 					Return(nil, nil, nil)
 
-				Expect(entry.AddEntry(tk, r, s)).To(Succeed())
+				Expect(entry.AddEntry(tk, g, p, r, s)).To(Succeed())
 			})
 		})
 
 		context("index does exist", func() {
+			it.Before(func() {
+				tk.On("GetInput", "address").Return("test-address", true)
+				tk.On("GetInput", "pull-request").Return("", false)
+				tk.On("GetInput", "version").Return("test-version", true)
+				tk.On("GetInput", "pre-release").Return("", false)
+				tk.On("GetInput", "require-monotonic-version").Return("", false)
+				tk.On("GetInput", "require-pre-release-flag").Return("", false)
+				tk.On("GetInput", "forbid-yanked-version-reuse").Return("", false)
+				tk.On("GetInput", "address-policy").Return("", false)
+			})
+
 			it("fails if version already exists", func() {
 				r.On("GetContents", mock.Anything, "test-owner", "test-repository", filepath.Join("te", "st", "test-namespace_test-name"), rOpts).
 					Return(&github.RepositoryContent{
@@ -103,7 +132,7 @@ func TestAddEntry(t *testing.T) {
 						SHA: github.Ptr("test-sha"),
 					}, nil, nil, nil)
 
-				Expect(entry.AddEntry(tk, r, s)).
+				Expect(entry.AddEntry(tk, g, p, r, s)).
 					To(MatchError("::error ::index test-name already has namespace test-namespace and version test-version"))
 			})
 
@@ -144,7 +173,199 @@ func TestAddEntry(t *testing.T) {
 				}).
 					Return(nil, nil, nil)
 
-				Expect(entry.AddEntry(tk, r, s)).To(Succeed())
+				Expect(entry.AddEntry(tk, g, p, r, s)).To(Succeed())
+			})
+		})
+
+		context("address policy", func() {
+			it.Before(func() {
+				tk.On("GetInput", "pull-request").Return("", false)
+				tk.On("GetInput", "version").Return("test-version", true)
+				tk.On("GetInput", "pre-release").Return("", false)
+				tk.On("GetInput", "require-monotonic-version").Return("", false)
+				tk.On("GetInput", "require-pre-release-flag").Return("", false)
+				tk.On("GetInput", "forbid-yanked-version-reuse").Return("", false)
+			})
+
+			context("namespace has a rule", func() {
+				it.Before(func() {
+					tk.On("GetInput", "address").Return("docker.io/test-namespace/test-name@sha256:133f2117e15569ca59645eddad78f4a6a675c435f9614e4b137364274f3a7614", true)
+					tk.On("GetInput", "address-policy").Return(`{"test-namespace": {"hosts": ["gcr.io"]}}`, true)
+				})
+
+				it("fails if address is not allowed", func() {
+					Expect(entry.AddEntry(tk, g, p, r, s)).
+						To(MatchError("::error ::registry host index.docker.io is not allowed for namespace test-namespace, allowed hosts are gcr.io"))
+				})
+			})
+
+			context("namespace has no rule", func() {
+				it.Before(func() {
+					tk.On("GetInput", "address").Return("test-address", true)
+					tk.On("GetInput", "address-policy").Return(`{"another-namespace": {"hosts": ["gcr.io"]}}`, true)
+				})
+
+				it("adds entry to index", func() {
+					r.On("GetContents", mock.Anything, "test-owner", "test-repository", filepath.Join("te", "st", "test-namespace_test-name"), rOpts).
+						Return(nil, nil, &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, nil)
+					r.On("CreateFile", mock.Anything, "test-owner", "test-repository", filepath.Join("te", "st", "test-namespace_test-name"), mock.Anything).
+						Return(nil, nil, nil)
+
+					Expect(entry.AddEntry(tk, g, p, r, s)).To(Succeed())
+				})
+			})
+		})
+
+		context("version policies", func() {
+			it.Before(func() {
+				tk.On("GetInput", "address").Return("test-address", true)
+				tk.On("GetInput", "pull-request").Return("", false)
+				tk.On("GetInput", "address-policy").Return("", false)
+				tk.On("GetInput", "require-monotonic-version").Return("true", true)
+				tk.On("GetInput", "require-pre-release-flag").Return("true", true)
+				tk.On("GetInput", "forbid-yanked-version-reuse").Return("true", true)
+
+				r.On("GetContents", mock.Anything, "test-owner", "test-repository", filepath.Join("te", "st", "test-namespace_test-name"), rOpts).
+					Return(&github.RepositoryContent{
+						Content: github.Ptr(fmt.Sprintf("%s\n%s\n%s\n",
+							asJSONString(index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "1.2.0", Address: "test-address"}),
+							asJSONString(index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "2.0.0", Yanked: true, Address: "test-address"}),
+							asJSONString(index.Entry{Namespace: "another-namespace", Name: "test-name", Version: "3.0.0", Address: "test-address"}),
+						)),
+						SHA: github.Ptr("test-sha"),
+					}, nil, nil, nil)
+			})
+
+			context("version is higher", func() {
+				it.Before(func() {
+					tk.On("GetInput", "version").Return("1.3.0", true)
+					tk.On("GetInput", "pre-release").Return("", false)
+				})
+
+				it("adds entry to index", func() {
+					r.On("CreateFile", mock.Anything, "test-owner", "test-repository", filepath.Join("te", "st", "test-namespace_test-name"), mock.Anything).
+						Return(nil, nil, nil)
+
+					Expect(entry.AddEntry(tk, g, p, r, s)).To(Succeed())
+				})
+			})
+
+			context("version is lower", func() {
+				it.Before(func() {
+					tk.On("GetInput", "version").Return("1.1.0", true)
+					tk.On("GetInput", "pre-release").Return("", false)
+				})
+
+				it("fails", func() {
+					Expect(entry.AddEntry(tk, g, p, r, s)).
+						To(MatchError("::error ::version 1.1.0 is not higher than 1.2.0, the highest release of test-namespace/test-name"))
+				})
+			})
+
+			context("pre-release is not flagged", func() {
+				it.Before(func() {
+					tk.On("GetInput", "version").Return("1.3.0-rc.1", true)
+					tk.On("GetInput", "pre-release").Return("", false)
+				})
+
+				it("fails", func() {
+					Expect(entry.AddEntry(tk, g, p, r, s)).
+						To(MatchError("::error ::version 1.3.0-rc.1 is a pre-release and must be flagged with pre-release"))
+				})
+			})
+
+			context("release is flagged", func() {
+				it.Before(func() {
+					tk.On("GetInput", "version").Return("1.3.0", true)
+					tk.On("GetInput", "pre-release").Return("true", true)
+				})
+
+				it("fails", func() {
+					Expect(entry.AddEntry(tk, g, p, r, s)).
+						To(MatchError("::error ::version 1.3.0 is flagged with pre-release but is not a pre-release"))
+				})
+			})
+
+			context("version was yanked", func() {
+				it.Before(func() {
+					tk.On("GetInput", "version").Return("2.0.0+rebuild", true)
+					tk.On("GetInput", "pre-release").Return("", false)
+				})
+
+				it("fails", func() {
+					Expect(entry.AddEntry(tk, g, p, r, s)).
+						To(MatchError("::error ::version 2.0.0+rebuild reuses yanked version 2.0.0 of test-namespace/test-name"))
+				})
+			})
+
+			context("exact version was yanked", func() {
+				it.Before(func() {
+					tk.On("GetInput", "version").Return("2.0.0", true)
+					tk.On("GetInput", "pre-release").Return("", false)
+				})
+
+				it("fails", func() {
+					Expect(entry.AddEntry(tk, g, p, r, s)).
+						To(MatchError("::error ::version 2.0.0 reuses yanked version 2.0.0 of test-namespace/test-name"))
+				})
+			})
+		})
+
+		context("pull request mode", func() {
+			it.Before(func() {
+				tk.On("GetInput", "address").Return("test-address", true)
+				tk.On("GetInput", "pull-request").Return("true", true)
+				tk.On("GetInput", "version").Return("test-version", true)
+				tk.On("GetInput", "pre-release").Return("", false)
+				tk.On("GetInput", "require-monotonic-version").Return("", false)
+				tk.On("GetInput", "require-pre-release-flag").Return("", false)
+				tk.On("GetInput", "forbid-yanked-version-reuse").Return("", false)
+				tk.On("GetInput", "address-policy").Return("", false)
+
+				r.On("GetContents", mock.Anything, "test-owner", "test-repository", filepath.Join("te", "st", "test-namespace_test-name"), rOpts).
+					Return(nil, nil, &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, nil)
+				r.On("Get", mock.Anything, "test-owner", "test-repository").
+					Return(&github.Repository{DefaultBranch: github.Ptr("main")}, nil, nil)
+				g.On("GetRef", mock.Anything, "test-owner", "test-repository", "heads/main").
+					Return(&github.Reference{Object: &github.GitObject{SHA: github.Ptr("test-head")}}, nil, nil)
+				g.On("GetRef", mock.Anything, "test-owner", "test-repository", "heads/add/test-namespace/test-name/test-version").
+					Return(nil, &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, &github.ErrorResponse{})
+				g.On("CreateRef", mock.Anything, "test-owner", "test-repository", github.CreateRef{
+					Ref: "refs/heads/add/test-namespace/test-name/test-version",
+					SHA: "test-head",
+				}).Return(&github.Reference{}, nil, nil)
+				r.On("CreateFile", mock.Anything, "test-owner", "test-repository", filepath.Join("te", "st", "test-namespace_test-name"), &github.RepositoryContentFileOptions{
+					Author: &github.CommitAuthor{
+						Name:  github.Ptr("buildpacks-bot"),
+						Email: github.Ptr("cncf-buildpacks-maintainers@lists.cncf.io"),
+					},
+					Message: github.Ptr("ADD test-namespace/test-name@test-version"),
+					Content: []byte(fmt.Sprintf("%s\n", asJSONString(index.Entry{
+						Namespace: "test-namespace",
+						Name:      "test-name",
+						Version:   "test-version",
+						Address:   "test-address",
+					}))),
+					Branch: github.Ptr("add/test-namespace/test-name/test-version"),
+				}).
+					Return(nil, nil, nil)
+				p.On("List", mock.Anything, "test-owner", "test-repository", &github.PullRequestListOptions{
+					State: "open",
+					Head:  "test-owner:add/test-namespace/test-name/test-version",
+					Base:  "main",
+				}).Return(nil, nil, nil)
+				p.On("Create", mock.Anything, "test-owner", "test-repository", &github.NewPullRequest{
+					Title: github.Ptr("ADD test-namespace/test-name@test-version"),
+					Head:  github.Ptr("add/test-namespace/test-name/test-version"),
+					Base:  github.Ptr("main"),
+					Body:  github.Ptr("Adds `test-namespace/test-name@test-version` with address `test-address` to the index."),
+				}).Return(&github.PullRequest{Number: github.Ptr(1), HTMLURL: github.Ptr("test-html-url")}, nil, nil)
+				tk.On("SetOutput", "pull-request-url", "test-html-url")
+				tk.On("SetOutput", "pull-request-number", "1")
+			})
+
+			it("opens pull request", func() {
+				Expect(entry.AddEntry(tk, g, p, r, s)).To(Succeed())
 			})
 		})
 	}, spec.Report(report.Terminal{}))
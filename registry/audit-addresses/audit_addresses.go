@@ -0,0 +1,258 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+
+	verify "github.com/buildpacks/github-actions/buildpackage/verify-metadata"
+	"github.com/buildpacks/github-actions/internal/toolkit"
+	"github.com/buildpacks/github-actions/registry/internal/index"
+)
+
+const (
+	ProblemInvalid      = "invalid"
+	ProblemMismatched   = "mismatched"
+	ProblemMissing      = "missing"
+	ProblemUnauthorized = "unauthorized"
+	ProblemUnavailable  = "unavailable"
+)
+
+type Problem struct {
+	ID      string `json:"id"`
+	Version string `json:"version"`
+	Address string `json:"address"`
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+}
+
+func AuditAddresses(tk toolkit.Toolkit, imageFn verify.ImageFunction) error {
+	c, err := parseConfig(tk)
+	if err != nil {
+		return err
+	}
+
+	entries, err := readEntries(c.Path)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Auditing %d entries with concurrency %d\n", len(entries), c.Concurrency)
+
+	var (
+		results   = make([]*Problem, len(entries))
+		semaphore = make(chan struct{}, c.Concurrency)
+		wg        sync.WaitGroup
+	)
+
+	for i, e := range entries {
+		wg.Add(1)
+		semaphore <- struct{}{}
+
+		go func() {
+			defer func() {
+				<-semaphore
+				wg.Done()
+			}()
+
+			results[i] = check(e, imageFn)
+		}()
+	}
+	wg.Wait()
+
+	problems := []Problem{}
+	for _, p := range results {
+		if p != nil {
+			tk.Errorf("%s@%s %s: %s", p.ID, p.Version, p.Kind, p.Message)
+			problems = append(problems, *p)
+		}
+	}
+
+	b, err := json.Marshal(problems)
+	if err != nil {
+		return toolkit.FailedErrorf("unable to marshal problems\n%w", err)
+	}
+	tk.SetOutput("problems", string(b))
+
+	if len(problems) > 0 {
+		return toolkit.FailedErrorf("%d of %d entries have problems with their images", len(problems), len(entries))
+	}
+
+	fmt.Printf("Verified %d entries\n", len(entries))
+	return nil
+}
+
+func check(e index.Entry, imageFn verify.ImageFunction) *Problem {
+	problem := func(kind string, format string, a ...interface{}) *Problem {
+		return &Problem{
+			ID:      fmt.Sprintf("%s/%s", e.Namespace, e.Name),
+			Version: e.Version,
+			Address: e.Address,
+			Kind:    kind,
+			Message: fmt.Sprintf(format, a...),
+		}
+	}
+
+	ref, err := name.NewDigest(e.Address)
+	if err != nil {
+		return problem(ProblemInvalid, "address %s is not in digest form", e.Address)
+	}
+
+	image, err := imageFn(ref)
+	if err != nil {
+		return problem(retrievalProblem(err), "unable to retrieve image %s\n%s", e.Address, err)
+	}
+
+	configFile, err := image.ConfigFile()
+	if err != nil {
+		return problem(retrievalProblem(err), "unable to retrieve config file of %s\n%s", e.Address, err)
+	}
+
+	raw, ok := configFile.Config.Labels[verify.MetadataLabel]
+	if !ok {
+		return problem(ProblemMismatched, "image %s has no %s label", e.Address, verify.MetadataLabel)
+	}
+
+	var m metadata
+	if err := json.Unmarshal([]byte(raw), &m); err != nil {
+		return problem(ProblemMismatched, "unable to unmarshal %s label of %s", verify.MetadataLabel, e.Address)
+	}
+
+	if id := fmt.Sprintf("%s/%s", e.Namespace, e.Name); m.ID != id || m.Version != e.Version {
+		return problem(ProblemMismatched, "image %s has %s@%s, expected %s@%s", e.Address, m.ID, m.Version, id, e.Version)
+	}
+
+	return nil
+}
+
+// retrievalProblem returns the kind of problem for an error retrieving an image.  Only a registry reporting the image
+// as not found makes it missing, so that credentials or outages are not mistaken for deleted images.
+func retrievalProblem(err error) string {
+	var t *transport.Error
+	if !errors.As(err, &t) {
+		return ProblemUnavailable
+	}
+
+	switch t.StatusCode {
+	case http.StatusNotFound:
+		return ProblemMissing
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return ProblemUnauthorized
+	}
+
+	for _, d := range t.Errors {
+		if d.Code == transport.ManifestUnknownErrorCode {
+			return ProblemMissing
+		}
+	}
+
+	return ProblemUnavailable
+}
+
+func readEntries(path string) ([]index.Entry, error) {
+	var entries []index.Entry
+
+	err := filepath.WalkDir(path, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if file != path && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		// only files laid out by index.Path are index files
+		ns, n, ok := strings.Cut(d.Name(), "_")
+		if !ok || n == "" {
+			return nil
+		}
+		if rel, err := filepath.Rel(path, file); err != nil || rel != index.Path(ns, n) {
+			return nil
+		}
+
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("unable to read %s\n%w", file, err)
+		}
+
+		e, err := index.UnmarshalEntries(string(b))
+		if err != nil {
+			return fmt.Errorf("unable to unmarshal entries of %s\n%w", file, err)
+		}
+
+		for _, entry := range e {
+			if !entry.Yanked {
+				entries = append(entries, entry)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, toolkit.FailedErrorf("unable to read index %s\n%w", path, err)
+	}
+
+	return entries, nil
+}
+
+type config struct {
+	Path        string
+	Concurrency int
+}
+
+func parseConfig(tk toolkit.Toolkit) (config, error) {
+	var (
+		c  config
+		ok bool
+	)
+
+	c.Path, ok = tk.GetInput("path")
+	if !ok {
+		return config{}, toolkit.FailedError("path must be set")
+	}
+
+	c.Concurrency = 4
+	if s, ok := tk.GetInput("concurrency"); ok {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 {
+			return config{}, toolkit.FailedErrorf("invalid concurrency %s, must be a positive integer", s)
+		}
+		c.Concurrency = n
+	}
+
+	return c, nil
+}
+
+type metadata struct {
+	ID      string
+	Version string
+}
@@ -0,0 +1,172 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package audit_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/stretchr/testify/mock"
+
+	verify "github.com/buildpacks/github-actions/buildpackage/verify-metadata"
+	"github.com/buildpacks/github-actions/internal/testutil"
+	"github.com/buildpacks/github-actions/internal/toolkit"
+	audit "github.com/buildpacks/github-actions/registry/audit-addresses"
+	"github.com/buildpacks/github-actions/registry/internal/index"
+)
+
+func TestAuditAddresses(t *testing.T) {
+	spec.Run(t, "audit-addresses", func(t *testing.T, context spec.G, it spec.S) {
+		var (
+			Expect           = NewWithT(t).Expect
+			ExpectWithOffset = NewWithT(t).ExpectWithOffset
+
+			host string
+			path string
+			tk   = &toolkit.MockToolkit{}
+		)
+
+		push := func(repository string, labels map[string]string) string {
+			_, digest := testutil.Push(t, host, repository, "latest", labels)
+			return fmt.Sprintf("%s/%s@%s", host, repository, digest)
+		}
+
+		buildpackage := func(repository string, id string, version string) string {
+			return push(repository, testutil.BuildpackageLabels(id, version))
+		}
+
+		write := func(entries ...index.Entry) {
+			s, err := index.MarshalEntries(entries)
+			ExpectWithOffset(1, err).NotTo(HaveOccurred())
+
+			testutil.WriteFile(t, filepath.Join(path, index.Path(entries[0].Namespace, entries[0].Name)), s)
+		}
+
+		problems := func() []audit.Problem {
+			var p []audit.Problem
+			ExpectWithOffset(1, json.Unmarshal([]byte(testutil.Output(tk, "problems")), &p)).To(Succeed())
+			return p
+		}
+
+		it.Before(func() {
+			host = testutil.Registry(t)
+			path = t.TempDir()
+
+			Expect(os.MkdirAll(filepath.Join(path, ".github"), 0755)).To(Succeed())
+			Expect(os.WriteFile(filepath.Join(path, "README.md"), []byte("test"), 0644)).To(Succeed())
+
+			tk.On("GetInput", "path").Return(path, true)
+			tk.On("GetInput", "concurrency").Return("2", true)
+			tk.On("SetOutput", "problems", mock.Anything)
+			tk.On("Errorf", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+
+		it("verifies entries", func() {
+			write(
+				index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "1.0.0", Address: buildpackage("test-name", "test-namespace/test-name", "1.0.0")},
+				index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "1.1.0", Address: buildpackage("test-name", "test-namespace/test-name", "1.1.0")},
+			)
+			write(index.Entry{Namespace: "test-namespace", Name: "ab", Version: "1.0.0", Address: buildpackage("ab", "test-namespace/ab", "1.0.0")})
+
+			Expect(audit.AuditAddresses(tk, remote.Image)).To(Succeed())
+			Expect(problems()).To(BeEmpty())
+		})
+
+		it("ignores yanked entries", func() {
+			write(
+				index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "1.0.0", Address: buildpackage("test-name", "test-namespace/test-name", "1.0.0")},
+				index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "0.9.0", Yanked: true, Address: fmt.Sprintf("%s/missing@sha256:%s", host, strings.Repeat("0", 64))},
+			)
+
+			Expect(audit.AuditAddresses(tk, remote.Image)).To(Succeed())
+		})
+
+		it("reports missing and mismatched images", func() {
+			missing := fmt.Sprintf("%s/missing@sha256:%s", host, strings.Repeat("0", 64))
+			mismatched := buildpackage("test-name", "test-namespace/test-name", "2.0.0")
+			unlabeled := push("test-name", map[string]string{})
+
+			write(
+				index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "1.0.0", Address: buildpackage("test-name", "test-namespace/test-name", "1.0.0")},
+				index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "1.1.0", Address: missing},
+				index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "1.2.0", Address: mismatched},
+				index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "1.3.0", Address: unlabeled},
+			)
+
+			Expect(audit.AuditAddresses(tk, remote.Image)).
+				To(MatchError("::error ::3 of 4 entries have problems with their images"))
+
+			p := problems()
+			Expect(p).To(HaveLen(3))
+			Expect(p[0].Version).To(Equal("1.1.0"))
+			Expect(p[0].Kind).To(Equal(audit.ProblemMissing))
+			Expect(p[1]).To(Equal(audit.Problem{
+				ID:      "test-namespace/test-name",
+				Version: "1.2.0",
+				Address: mismatched,
+				Kind:    audit.ProblemMismatched,
+				Message: fmt.Sprintf("image %s has test-namespace/test-name@2.0.0, expected test-namespace/test-name@1.2.0", mismatched),
+			}))
+			Expect(p[2].Version).To(Equal("1.3.0"))
+			Expect(p[2].Message).To(Equal(fmt.Sprintf("image %s has no %s label", unlabeled, verify.MetadataLabel)))
+		})
+
+		it("reports images that cannot be retrieved separately from missing images", func() {
+			unauthorized := fmt.Sprintf("%s/unauthorized@sha256:%s", host, strings.Repeat("0", 64))
+			unavailable := fmt.Sprintf("%s/unavailable@sha256:%s", host, strings.Repeat("0", 64))
+			unreachable := fmt.Sprintf("%s/unreachable@sha256:%s", host, strings.Repeat("0", 64))
+
+			write(
+				index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "1.0.0", Address: unauthorized},
+				index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "1.1.0", Address: unavailable},
+				index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "1.2.0", Address: unreachable},
+			)
+
+			imageFn := func(ref name.Reference, _ ...remote.Option) (v1.Image, error) {
+				switch ref.Context().RepositoryStr() {
+				case "unauthorized":
+					return nil, &transport.Error{StatusCode: http.StatusUnauthorized}
+				case "unavailable":
+					return nil, &transport.Error{StatusCode: http.StatusServiceUnavailable}
+				default:
+					return nil, fmt.Errorf("test-error")
+				}
+			}
+
+			Expect(audit.AuditAddresses(tk, imageFn)).
+				To(MatchError("::error ::3 of 3 entries have problems with their images"))
+
+			p := problems()
+			Expect(p).To(HaveLen(3))
+			Expect(p[0].Kind).To(Equal(audit.ProblemUnauthorized))
+			Expect(p[1].Kind).To(Equal(audit.ProblemUnavailable))
+			Expect(p[2].Kind).To(Equal(audit.ProblemUnavailable))
+		})
+	}, spec.Report(report.Terminal{}))
+}
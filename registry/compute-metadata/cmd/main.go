@@ -22,10 +22,34 @@ import (
 
 	"github.com/buildpacks/github-actions/internal/toolkit"
 	"github.com/buildpacks/github-actions/registry/compute-metadata"
+	"github.com/buildpacks/github-actions/registry/internal/credentials"
+	"github.com/buildpacks/github-actions/registry/internal/services"
 )
 
 func main() {
-	if err := metadata.ComputeMetadata(&toolkit.DefaultToolkit{}); err != nil {
+	tk := &toolkit.DefaultToolkit{}
+
+	var (
+		issues        services.IssuesService
+		organizations services.OrganizationsService
+		repositories  services.RepositoriesService
+	)
+
+	_, token := tk.GetInput("token")
+	_, app := tk.GetInput("app-id")
+	if token || app {
+		gh, err := credentials.NewClient(tk)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+
+		issues = gh.Issues
+		organizations = gh.Organizations
+		repositories = gh.Repositories
+	}
+
+	if err := metadata.ComputeMetadata(tk, issues, organizations, repositories); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
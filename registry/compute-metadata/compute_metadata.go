@@ -18,18 +18,20 @@ package metadata
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
-	"strings"
+	"strconv"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/google/go-github/v89/github"
-	"github.com/pelletier/go-toml/v2"
 
 	"github.com/buildpacks/github-actions/internal/toolkit"
 	"github.com/buildpacks/github-actions/registry/internal/index"
 	"github.com/buildpacks/github-actions/registry/internal/namespace"
+	"github.com/buildpacks/github-actions/registry/internal/services"
 )
 
-func ComputeMetadata(tk toolkit.Toolkit) error {
+func ComputeMetadata(tk toolkit.Toolkit, issues services.IssuesService, organizations services.OrganizationsService, repositories services.RepositoriesService) error {
 	c, err := parseConfig(tk)
 	if err != nil {
 		return err
@@ -40,32 +42,49 @@ func ComputeMetadata(tk toolkit.Toolkit) error {
 		return toolkit.FailedErrorf("unable to unmarshal issue\n%w", err)
 	}
 
-	var request index.Request
-	if err := toml.Unmarshal([]byte(strings.ReplaceAll(*issue.Body, "```", "")), &request); err != nil {
-		return toolkit.FailedErrorf("unable to unmarshal body\n%w", err)
-	}
+	request, ns, name, problems := validate(issue)
+	if len(problems) == 0 && !request.Yank && !request.Deprecate {
+		var owner, repository string
+		if _, ok := index.AddressPolicyFile(tk); ok {
+			if owner, repository, err = index.IssueRepository(issue); err != nil {
+				return toolkit.FailedError(err)
+			}
+		}
 
-	var (
-		ns   string
-		name string
-	)
-	if g := index.ValidRequestId.FindStringSubmatch(request.ID); g == nil {
-		return toolkit.FailedErrorf("invalid id %s", request.ID)
-	} else {
-		ns = g[1]
-		name = g[2]
+		policy, err := index.LoadAddressPolicy(tk, repositories, owner, repository)
+		if err != nil {
+			return err
+		}
+
+		if err := policy.Check(ns, request.Address); err != nil {
+			problems = append(problems, index.Problem{
+				Message: err.Error(),
+				Remedy:  "Publish the buildpackage to a registry host and repository allowed for the namespace by the registry address policy.",
+			})
+		}
 	}
 
-	if namespace.IsRestricted(ns) {
-		return toolkit.FailedErrorf("restricted namespace %s", ns)
+	if c.VerifyOwner && (organizations == nil || repositories == nil) {
+		return toolkit.FailedError("token must be set to verify owner")
 	}
 
-	if !index.ValidRequestVersion.MatchString(request.Version) {
-		return toolkit.FailedErrorf("invalid version %s", request.Version)
+	if len(problems) == 0 && c.VerifyOwner {
+		problems, err = verifyOwner(c, issue.User, ns, organizations, repositories)
+		if err != nil {
+			return err
+		}
 	}
 
-	if !request.Yank && !index.ValidRequestAddress.MatchString(request.Address) {
-		return toolkit.FailedErrorf("invalid address %s", request.Address)
+	if len(problems) > 0 {
+		if issues != nil {
+			if owner, repository, err := index.IssueRepository(issue); err != nil {
+				tk.Warningf("unable to report problems\n%s", err)
+			} else if err := index.ReportProblems(issues, owner, repository, issue.GetNumber(), problems); err != nil {
+				tk.Warningf("unable to report problems\n%s", err)
+			}
+		}
+
+		return problems
 	}
 
 	fmt.Printf(`Metadata:
@@ -81,15 +100,149 @@ func ComputeMetadata(tk toolkit.Toolkit) error {
 	tk.SetOutput("name", name)
 	tk.SetOutput("version", request.Version)
 
-	if !request.Yank {
+	if issue.User != nil {
+		tk.SetOutput("user-login", issue.User.GetLogin())
+		tk.SetOutput("user-id", strconv.FormatInt(issue.User.GetID(), 10))
+	}
+
+	if request.Yank || request.Deprecate {
+		tk.SetOutput("deprecate", strconv.FormatBool(request.Deprecate))
+		tk.SetOutput("range", request.Range)
+		tk.SetOutput("force", strconv.FormatBool(request.Force))
+		tk.SetOutput("reason", request.Reason)
+		tk.SetOutput("replacement-version", request.Replacement)
+	} else {
 		tk.SetOutput("address", request.Address)
+		tk.SetOutput("pre-release", strconv.FormatBool(request.PreRelease))
 	}
 
 	return nil
 }
 
+func validate(issue github.Issue) (index.Request, string, string, index.Problems) {
+	request, err := index.ParseRequest(issue.GetBody())
+	if err != nil {
+		var errs index.BodyErrors
+		if !errors.As(err, &errs) {
+			return index.Request{}, "", "", index.Problems{{Message: fmt.Sprintf("unable to parse body\n%s", err)}}
+		}
+
+		var problems index.Problems
+		for _, e := range errs {
+			problems = append(problems, index.Problem{
+				Message: fmt.Sprintf("invalid body at %s", e),
+				Remedy: "The issue body must contain either exactly one ```toml code block or the sections of the request issue form, " +
+					"using only the `id`, `version`, `addr`, `yank`, `deprecate`, `range`, `force`, `reason`, `replacement-version`, and `pre-release` keys, each at most once.",
+			})
+		}
+		return index.Request{}, "", "", problems
+	}
+
+	var (
+		problems index.Problems
+		ns       string
+		name     string
+	)
+
+	if g := index.ValidRequestId.FindStringSubmatch(request.ID); g == nil {
+		problems = append(problems, index.Problem{
+			Message: fmt.Sprintf("invalid id %s", request.ID),
+			Remedy:  "`id` must be in `{namespace}/{name}` format, using only letters, numbers, `.`, `-`, and `/`.",
+		})
+	} else {
+		ns = g[1]
+		name = g[2]
+	}
+
+	if namespace.IsRestricted(ns) {
+		problems = append(problems, index.Problem{
+			Message: fmt.Sprintf("restricted namespace %s", ns),
+			Remedy:  fmt.Sprintf("The `%s` namespace is reserved.  Use a namespace owned by you or your organization.", ns),
+		})
+	}
+
+	if request.Range != "" {
+		if !request.Yank && !request.Deprecate {
+			problems = append(problems, index.Problem{
+				Message: fmt.Sprintf("range %s requires yank or deprecate", request.Range),
+				Remedy:  "`range` can only be used together with `yank = true` or `deprecate = true`.",
+			})
+		} else if _, err := semver.NewConstraint(request.Range); err != nil {
+			problems = append(problems, index.Problem{
+				Message: fmt.Sprintf("invalid range %s", request.Range),
+				Remedy:  "`range` must be a semantic version constraint such as `>=1.0.0 <1.4.2`.",
+			})
+		}
+	}
+
+	if (request.Range == "" || request.Version != "") && !index.ValidRequestVersion.MatchString(request.Version) {
+		problems = append(problems, index.Problem{
+			Message: fmt.Sprintf("invalid version %s", request.Version),
+			Remedy:  "`version` must be a semantic version such as `1.2.3`.",
+		})
+	}
+
+	if request.Yank && request.Deprecate {
+		problems = append(problems, index.Problem{
+			Message: "yank and deprecate are mutually exclusive",
+			Remedy:  "Set either `yank = true` to block resolution of the version or `deprecate = true` to only warn about it.",
+		})
+	}
+
+	if request.Replacement != "" && !index.ValidRequestVersion.MatchString(request.Replacement) {
+		problems = append(problems, index.Problem{
+			Message: fmt.Sprintf("invalid replacement-version %s", request.Replacement),
+			Remedy:  "`replacement-version` must be a semantic version such as `1.2.3`.",
+		})
+	}
+
+	if !request.Yank && !request.Deprecate {
+		if d, err := index.ParseAddress(request.Address); err != nil {
+			problems = append(problems, index.Problem{
+				Message: err.Error(),
+				Remedy:  "`addr` must be an image reference in digest form `{host}/{repository}@sha256:{digest}`.",
+			})
+		} else {
+			request.Address = d.Name()
+		}
+	}
+
+	return request, ns, name, problems
+}
+
+func verifyOwner(c config, user *github.User, ns string, organizations services.OrganizationsService, repositories services.RepositoriesService) (index.Problems, error) {
+	if user == nil || user.ID == nil || user.Login == nil {
+		return index.Problems{{Message: "issue has no author"}}, nil
+	}
+
+	n, err := namespace.Read(repositories, c.Owner, c.Repository, ns)
+	if errors.Is(err, namespace.ErrNotFound) {
+		return index.Problems{{
+			Message: fmt.Sprintf("invalid namespace %s", ns),
+			Remedy:  "The namespace must be registered before buildpacks can be added to it.",
+		}}, nil
+	} else if err != nil {
+		return nil, toolkit.FailedError(err)
+	}
+
+	if ok, err := namespace.IsUserOwner(n.Owners, *user, organizations); err != nil {
+		return nil, toolkit.FailedError(err)
+	} else if ok {
+		fmt.Printf("Verified %s is an owner of %s\n", *user.Login, ns)
+		return nil, nil
+	}
+
+	return index.Problems{{
+		Message: fmt.Sprintf("%s is not an owner of %s", *user.Login, ns),
+		Remedy:  "Open the request from a GitHub account that owns the namespace, or that is a member of an organization that owns it.",
+	}}, nil
+}
+
 type config struct {
-	Issue string
+	Issue       string
+	VerifyOwner bool
+	Owner       string
+	Repository  string
 }
 
 func parseConfig(tk toolkit.Toolkit) (config, error) {
@@ -103,5 +256,23 @@ func parseConfig(tk toolkit.Toolkit) (config, error) {
 		return config{}, toolkit.FailedError("issue must be set")
 	}
 
+	if s, ok := tk.GetInput("verify-owner"); ok {
+		if t, err := strconv.ParseBool(s); err == nil {
+			c.VerifyOwner = t
+		}
+	}
+
+	if c.VerifyOwner {
+		c.Owner, ok = tk.GetInput("owner")
+		if !ok {
+			return config{}, toolkit.FailedError("owner must be set")
+		}
+
+		c.Repository, ok = tk.GetInput("repository")
+		if !ok {
+			return config{}, toolkit.FailedError("repository must be set")
+		}
+	}
+
 	return c, nil
 }
@@ -19,6 +19,8 @@ package metadata_test
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/go-github/v89/github"
@@ -26,10 +28,13 @@ import (
 	"github.com/pelletier/go-toml/v2"
 	"github.com/sclevine/spec"
 	"github.com/sclevine/spec/report"
+	"github.com/stretchr/testify/mock"
 
 	"github.com/buildpacks/github-actions/internal/toolkit"
 	metadata "github.com/buildpacks/github-actions/registry/compute-metadata"
 	"github.com/buildpacks/github-actions/registry/internal/index"
+	"github.com/buildpacks/github-actions/registry/internal/namespace"
+	"github.com/buildpacks/github-actions/registry/internal/services"
 )
 
 func TestComputeMetadata(t *testing.T) {
@@ -38,7 +43,11 @@ func TestComputeMetadata(t *testing.T) {
 			Expect           = NewWithT(t).Expect
 			ExpectWithOffset = NewWithT(t).ExpectWithOffset
 
-			tk = &toolkit.MockToolkit{}
+			i     = &services.MockIssuesService{}
+			o     = &services.MockOrganizationsService{}
+			r     = &services.MockRepositoriesService{}
+			rOpts *github.RepositoryContentGetOptions
+			tk    = &toolkit.MockToolkit{}
 		)
 
 		asJSONString := func(v interface{}) string {
@@ -55,6 +64,17 @@ func TestComputeMetadata(t *testing.T) {
 			return string(b)
 		}
 
+		asBody := func(v interface{}) string {
+			return fmt.Sprintf("```toml\n%s```\n", asTOMLString(v))
+		}
+
+		// contexts that set these inputs start from a new toolkit
+		it.Before(func() {
+			tk.On("GetInput", "verify-owner").Return("", false)
+			tk.On("GetInput", "address-policy").Return("", false)
+			tk.On("GetInput", "address-policy-file").Return("", false)
+		})
+
 		it("returns error when id is invalid", func() {
 			tk.On("GetInput", "issue").Return(asJSONString(github.Issue{
 				Body: github.Ptr(fmt.Sprintf("```\n%s\n```", asTOMLString(index.Request{
@@ -62,17 +82,17 @@ func TestComputeMetadata(t *testing.T) {
 				}))),
 			}), true)
 
-			Expect(metadata.ComputeMetadata(tk)).To(MatchError("::error ::invalid id test@namespace/test-name"))
+			Expect(metadata.ComputeMetadata(tk, nil, nil, nil)).To(MatchError("::error ::invalid id test@namespace/test-name%0Ainvalid version %0Aaddress must be set"))
 		})
 
 		it("returns error if namespace is restricted", func() {
 			tk.On("GetInput", "issue").Return(asJSONString(github.Issue{
-				Body: github.Ptr(asTOMLString(index.Request{
+				Body: github.Ptr(asBody(index.Request{
 					ID: "cnb/test-name",
 				})),
 			}), true)
 
-			Expect(metadata.ComputeMetadata(tk)).To(MatchError("::error ::restricted namespace cnb"))
+			Expect(metadata.ComputeMetadata(tk, nil, nil, nil)).To(MatchError("::error ::restricted namespace cnb%0Ainvalid version %0Aaddress must be set"))
 		})
 
 		it("returns error when version is invalid", func() {
@@ -83,24 +103,45 @@ func TestComputeMetadata(t *testing.T) {
 				}))),
 			}), true)
 
-			Expect(metadata.ComputeMetadata(tk)).To(MatchError("::error ::invalid version test-version"))
+			Expect(metadata.ComputeMetadata(tk, nil, nil, nil)).To(MatchError("::error ::invalid version test-version%0Aaddress must be set"))
 		})
 
 		it("returns error when yank is false and address is invalid", func() {
 			tk.On("GetInput", "issue").Return(asJSONString(github.Issue{
-				Body: github.Ptr(asTOMLString(index.Request{
+				Body: github.Ptr(asBody(index.Request{
 					ID:      "test-namespace/test-name",
 					Version: "0.0.0",
 					Address: "host.com:443/repository/image:tag",
 				})),
 			}), true)
 
-			Expect(metadata.ComputeMetadata(tk)).To(MatchError("::error ::invalid address host.com:443/repository/image:tag"))
+			Expect(metadata.ComputeMetadata(tk, nil, nil, nil)).To(MatchError("::error ::address host.com:443/repository/image:tag must be in digest form {host}/{repository}@sha256:{digest}"))
+		})
+
+		it("reports problems to issue", func() {
+			tk.On("GetInput", "issue").Return(asJSONString(github.Issue{
+				Number:        github.Ptr(1),
+				RepositoryURL: github.Ptr("https://api.github.com/repos/test-owner/test-repository"),
+				Body: github.Ptr(asBody(index.Request{
+					ID:      "test-namespace/test-name",
+					Version: "test-version",
+					Yank:    true,
+				})),
+			}), true)
+
+			i.On("CreateComment", mock.Anything, "test-owner", "test-repository", 1, &github.IssueComment{
+				Body: github.Ptr("This request could not be processed:\n\n* **invalid version test-version**\n  `version` must be a semantic version such as `1.2.3`.\n"),
+			}).Return(&github.IssueComment{}, nil, nil)
+			i.On("AddLabelsToIssue", mock.Anything, "test-owner", "test-repository", 1, []string{index.RequestFailureLabel}).
+				Return(nil, nil, nil)
+
+			Expect(metadata.ComputeMetadata(tk, i, nil, nil)).To(MatchError("::error ::invalid version test-version"))
+			i.AssertExpectations(t)
 		})
 
 		it("computes metadata when yank is false", func() {
 			tk.On("GetInput", "issue").Return(asJSONString(github.Issue{
-				Body: github.Ptr(asTOMLString(index.Request{
+				Body: github.Ptr(asBody(index.Request{
 					ID:      "test-namespace/test-name",
 					Version: "0.0.0",
 					Address: "host.com:443/repository/image@sha256:133f2117e15569ca59645eddad78f4a6a675c435f9614e4b137364274f3a7614",
@@ -109,15 +150,16 @@ func TestComputeMetadata(t *testing.T) {
 			tk.On("SetOutput", "id", "test-namespace/test-name")
 			tk.On("SetOutput", "version", "0.0.0")
 			tk.On("SetOutput", "address", "host.com:443/repository/image@sha256:133f2117e15569ca59645eddad78f4a6a675c435f9614e4b137364274f3a7614")
+			tk.On("SetOutput", "pre-release", "false")
 			tk.On("SetOutput", "namespace", "test-namespace")
 			tk.On("SetOutput", "name", "test-name")
 
-			Expect(metadata.ComputeMetadata(tk)).To(Succeed())
+			Expect(metadata.ComputeMetadata(tk, nil, nil, nil)).To(Succeed())
 		})
 
 		it("computes metadata when yank is true", func() {
 			tk.On("GetInput", "issue").Return(asJSONString(github.Issue{
-				Body: github.Ptr(asTOMLString(index.Request{
+				Body: github.Ptr(asBody(index.Request{
 					ID:      "test-namespace/test-name",
 					Version: "0.0.0",
 					Yank:    true,
@@ -127,8 +169,277 @@ func TestComputeMetadata(t *testing.T) {
 			tk.On("SetOutput", "version", "0.0.0")
 			tk.On("SetOutput", "namespace", "test-namespace")
 			tk.On("SetOutput", "name", "test-name")
+			tk.On("SetOutput", "deprecate", "false")
+			tk.On("SetOutput", "range", "")
+			tk.On("SetOutput", "force", "false")
+			tk.On("SetOutput", "reason", "")
+			tk.On("SetOutput", "replacement-version", "")
+
+			Expect(metadata.ComputeMetadata(tk, nil, nil, nil)).To(Succeed())
+		})
+
+		it("computes metadata when deprecate is true", func() {
+			tk.On("GetInput", "issue").Return(asJSONString(github.Issue{
+				Body: github.Ptr(asBody(index.Request{
+					ID:          "test-namespace/test-name",
+					Version:     "0.0.0",
+					Deprecate:   true,
+					Reason:      "test-reason",
+					Replacement: "1.0.0",
+				})),
+			}), true)
+			tk.On("SetOutput", "id", "test-namespace/test-name")
+			tk.On("SetOutput", "version", "0.0.0")
+			tk.On("SetOutput", "namespace", "test-namespace")
+			tk.On("SetOutput", "name", "test-name")
+			tk.On("SetOutput", "deprecate", "true")
+			tk.On("SetOutput", "range", "")
+			tk.On("SetOutput", "force", "false")
+			tk.On("SetOutput", "reason", "test-reason")
+			tk.On("SetOutput", "replacement-version", "1.0.0")
+
+			Expect(metadata.ComputeMetadata(tk, nil, nil, nil)).To(Succeed())
+		})
+
+		it("returns error when yank and deprecate are both true", func() {
+			tk.On("GetInput", "issue").Return(asJSONString(github.Issue{
+				Body: github.Ptr(asBody(index.Request{
+					ID:          "test-namespace/test-name",
+					Version:     "0.0.0",
+					Yank:        true,
+					Deprecate:   true,
+					Replacement: "test-version",
+				})),
+			}), true)
+
+			Expect(metadata.ComputeMetadata(tk, nil, nil, nil)).
+				To(MatchError("::error ::yank and deprecate are mutually exclusive%0Ainvalid replacement-version test-version"))
+		})
+
+		it("computes metadata when yanking a range", func() {
+			tk.On("GetInput", "issue").Return(asJSONString(github.Issue{
+				Body: github.Ptr(asBody(index.Request{
+					ID:    "test-namespace/test-name",
+					Range: ">=1.0.0 <1.4.2",
+					Force: true,
+					Yank:  true,
+				})),
+			}), true)
+			tk.On("SetOutput", "id", "test-namespace/test-name")
+			tk.On("SetOutput", "version", "")
+			tk.On("SetOutput", "namespace", "test-namespace")
+			tk.On("SetOutput", "name", "test-name")
+			tk.On("SetOutput", "deprecate", "false")
+			tk.On("SetOutput", "range", ">=1.0.0 <1.4.2")
+			tk.On("SetOutput", "force", "true")
+			tk.On("SetOutput", "reason", "")
+			tk.On("SetOutput", "replacement-version", "")
+
+			Expect(metadata.ComputeMetadata(tk, nil, nil, nil)).To(Succeed())
+		})
+
+		it("returns error when range is used to add", func() {
+			tk.On("GetInput", "issue").Return(asJSONString(github.Issue{
+				Body: github.Ptr(asBody(index.Request{
+					ID:      "test-namespace/test-name",
+					Version: "0.0.0",
+					Range:   ">=1.0.0",
+					Address: "host.com:443/repository/image@sha256:133f2117e15569ca59645eddad78f4a6a675c435f9614e4b137364274f3a7614",
+				})),
+			}), true)
+
+			Expect(metadata.ComputeMetadata(tk, nil, nil, nil)).To(MatchError("::error ::range >=1.0.0 requires yank or deprecate"))
+		})
+
+		it("returns error when body has unknown keys", func() {
+			tk.On("GetInput", "issue").Return(asJSONString(github.Issue{
+				Body: github.Ptr("Please add my buildpack\n\n```toml\nid = \"test-namespace/test-name\"\nversion = \"0.0.0\"\nadress = \"test-address\"\n```\n"),
+			}), true)
+
+			Expect(metadata.ComputeMetadata(tk, nil, nil, nil)).To(MatchError("::error ::invalid body at line 6, column 1: unknown key adress"))
+		})
+
+		it("computes metadata from issue form", func() {
+			tk.On("GetInput", "issue").Return(asJSONString(github.Issue{
+				Body: github.Ptr("### ID\n\ntest-namespace/test-name\n\n### Version\n\n0.0.0\n\n" +
+					"### Address\n\nhost.com:443/repository/image@sha256:133f2117e15569ca59645eddad78f4a6a675c435f9614e4b137364274f3a7614\n"),
+			}), true)
+			tk.On("SetOutput", "id", "test-namespace/test-name")
+			tk.On("SetOutput", "version", "0.0.0")
+			tk.On("SetOutput", "address", "host.com:443/repository/image@sha256:133f2117e15569ca59645eddad78f4a6a675c435f9614e4b137364274f3a7614")
+			tk.On("SetOutput", "pre-release", "false")
+			tk.On("SetOutput", "namespace", "test-namespace")
+			tk.On("SetOutput", "name", "test-name")
+
+			Expect(metadata.ComputeMetadata(tk, nil, nil, nil)).To(Succeed())
+		})
+
+		it("computes pre-release flag from issue form", func() {
+			tk.On("GetInput", "issue").Return(asJSONString(github.Issue{
+				Body: github.Ptr("### ID\n\ntest-namespace/test-name\n\n### Version\n\n0.0.0-rc.1\n\n" +
+					"### Address\n\nhost.com:443/repository/image@sha256:133f2117e15569ca59645eddad78f4a6a675c435f9614e4b137364274f3a7614\n\n" +
+					"### Pre-release\n\n- [x] This version is a pre-release\n"),
+			}), true)
+			tk.On("SetOutput", "id", "test-namespace/test-name")
+			tk.On("SetOutput", "version", "0.0.0-rc.1")
+			tk.On("SetOutput", "address", "host.com:443/repository/image@sha256:133f2117e15569ca59645eddad78f4a6a675c435f9614e4b137364274f3a7614")
+			tk.On("SetOutput", "pre-release", "true")
+			tk.On("SetOutput", "namespace", "test-namespace")
+			tk.On("SetOutput", "name", "test-name")
+
+			Expect(metadata.ComputeMetadata(tk, nil, nil, nil)).To(Succeed())
+		})
+
+		it("normalizes address", func() {
+			tk.On("GetInput", "issue").Return(asJSONString(github.Issue{
+				Body: github.Ptr(asBody(index.Request{
+					ID:      "test-namespace/test-name",
+					Version: "0.0.0",
+					Address: "docker.io/image:tag@sha256:133F2117E15569CA59645EDDAD78F4A6A675C435F9614E4B137364274F3A7614",
+				})),
+			}), true)
+			tk.On("SetOutput", "id", "test-namespace/test-name")
+			tk.On("SetOutput", "version", "0.0.0")
+			tk.On("SetOutput", "address", "index.docker.io/library/image@sha256:133f2117e15569ca59645eddad78f4a6a675c435f9614e4b137364274f3a7614")
+			tk.On("SetOutput", "pre-release", "false")
+			tk.On("SetOutput", "namespace", "test-namespace")
+			tk.On("SetOutput", "name", "test-name")
+
+			Expect(metadata.ComputeMetadata(tk, nil, nil, nil)).To(Succeed())
+		})
+
+		it("computes metadata when issue repository is unknown and address policy is not set", func() {
+			tk.On("GetInput", "issue").Return(asJSONString(github.Issue{
+				Number: github.Ptr(1),
+				Body: github.Ptr(asBody(index.Request{
+					ID:      "test-namespace/test-name",
+					Version: "0.0.0",
+					Address: "host.com:443/repository/image@sha256:133f2117e15569ca59645eddad78f4a6a675c435f9614e4b137364274f3a7614",
+				})),
+			}), true)
+			tk.On("SetOutput", mock.Anything, mock.Anything)
+
+			Expect(metadata.ComputeMetadata(tk, nil, nil, nil)).To(Succeed())
+		})
+
+		context("address policy is set", func() {
+			it.Before(func() {
+				tk = &toolkit.MockToolkit{}
+
+				tk.On("GetInput", "verify-owner").Return("", false)
+				tk.On("GetInput", "address-policy").Return("", false)
+				tk.On("GetInput", "address-policy-file").Return("policy.json", true)
+				r.On("GetContents", mock.Anything, "test-owner", "test-repository", "policy.json", rOpts).
+					Return(&github.RepositoryContent{
+						Content: github.Ptr(`{"test-namespace": {"hosts": ["gcr.io"], "repository-prefixes": ["gcr.io/test-namespace"]}}`),
+					}, nil, nil, nil)
+			})
+
+			it("computes metadata when address is allowed", func() {
+				tk.On("GetInput", "issue").Return(asJSONString(github.Issue{
+					RepositoryURL: github.Ptr("https://api.github.com/repos/test-owner/test-repository"),
+					Body: github.Ptr(asBody(index.Request{
+						ID:      "test-namespace/test-name",
+						Version: "0.0.0",
+						Address: "gcr.io/test-namespace/image@sha256:133f2117e15569ca59645eddad78f4a6a675c435f9614e4b137364274f3a7614",
+					})),
+				}), true)
+				tk.On("SetOutput", mock.Anything, mock.Anything)
+
+				Expect(metadata.ComputeMetadata(tk, nil, nil, r)).To(Succeed())
+			})
+
+			it("returns error naming host when address is not allowed", func() {
+				tk.On("GetInput", "issue").Return(asJSONString(github.Issue{
+					RepositoryURL: github.Ptr("https://api.github.com/repos/test-owner/test-repository"),
+					Body: github.Ptr(asBody(index.Request{
+						ID:      "test-namespace/test-name",
+						Version: "0.0.0",
+						Address: "ttl.sh/image@sha256:133f2117e15569ca59645eddad78f4a6a675c435f9614e4b137364274f3a7614",
+					})),
+				}), true)
+
+				Expect(metadata.ComputeMetadata(tk, nil, nil, r)).
+					To(MatchError("::error ::registry host ttl.sh is not allowed for namespace test-namespace, allowed hosts are gcr.io"))
+			})
+
+			it("returns error when issue repository is unknown", func() {
+				tk.On("GetInput", "issue").Return(asJSONString(github.Issue{
+					Number: github.Ptr(1),
+					Body: github.Ptr(asBody(index.Request{
+						ID:      "test-namespace/test-name",
+						Version: "0.0.0",
+						Address: "host.com:443/repository/image@sha256:133f2117e15569ca59645eddad78f4a6a675c435f9614e4b137364274f3a7614",
+					})),
+				}), true)
+
+				Expect(metadata.ComputeMetadata(tk, nil, nil, r)).To(MatchError(`::error ::unable to determine repository of issue 1 from repository URL ""`))
+			})
+
+			it("returns error if services are not available", func() {
+				tk.On("GetInput", "issue").Return(asJSONString(github.Issue{
+					RepositoryURL: github.Ptr("https://api.github.com/repos/test-owner/test-repository"),
+					Body: github.Ptr(asBody(index.Request{
+						ID:      "test-namespace/test-name",
+						Version: "0.0.0",
+						Address: "gcr.io/test-namespace/image@sha256:133f2117e15569ca59645eddad78f4a6a675c435f9614e4b137364274f3a7614",
+					})),
+				}), true)
+
+				Expect(metadata.ComputeMetadata(tk, nil, nil, nil)).To(MatchError("::error ::token must be set to read address-policy-file"))
+			})
+		})
+
+		context("owner is verified", func() {
+			it.Before(func() {
+				tk = &toolkit.MockToolkit{}
+
+				tk.On("GetInput", "verify-owner").Return("true", true)
+				tk.On("GetInput", "owner").Return("test-owner", true)
+				tk.On("GetInput", "repository").Return("test-repository", true)
+				tk.On("GetInput", "issue").Return(asJSONString(github.Issue{
+					User: &github.User{ID: github.Ptr(int64(1)), Login: github.Ptr("test-user")},
+					Body: github.Ptr(asBody(index.Request{
+						ID:      "test-namespace/test-name",
+						Version: "0.0.0",
+						Yank:    true,
+					})),
+				}), true)
+			})
+
+			it("outputs author if owner", func() {
+				r.On("GetContents", mock.Anything, "test-owner", "test-repository", filepath.Join("v1", "test-namespace.json"), rOpts).
+					Return(&github.RepositoryContent{
+						Content: github.Ptr(asJSONString(namespace.Namespace{Owners: []namespace.Owner{{ID: 1, Type: namespace.UserType}}})),
+					}, nil, nil, nil)
+				tk.On("SetOutput", mock.Anything, mock.Anything)
+
+				Expect(metadata.ComputeMetadata(tk, nil, o, r)).To(Succeed())
+				tk.AssertCalled(t, "SetOutput", "user-login", "test-user")
+				tk.AssertCalled(t, "SetOutput", "user-id", "1")
+			})
+
+			it("returns error if not owner", func() {
+				r.On("GetContents", mock.Anything, "test-owner", "test-repository", filepath.Join("v1", "test-namespace.json"), rOpts).
+					Return(&github.RepositoryContent{
+						Content: github.Ptr(asJSONString(namespace.Namespace{Owners: []namespace.Owner{{ID: 2, Type: namespace.UserType}}})),
+					}, nil, nil, nil)
+				o.On("List", mock.Anything, "test-user", mock.Anything).
+					Return([]*github.Organization{{ID: github.Ptr(int64(3))}}, &github.Response{}, nil)
+
+				Expect(metadata.ComputeMetadata(tk, nil, o, r)).To(MatchError("::error ::test-user is not an owner of test-namespace"))
+			})
+
+			it("returns error if namespace does not exist", func() {
+				r.On("GetContents", mock.Anything, "test-owner", "test-repository", filepath.Join("v1", "test-namespace.json"), rOpts).
+					Return(nil, nil, &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, nil)
+
+				Expect(metadata.ComputeMetadata(tk, nil, o, r)).To(MatchError("::error ::invalid namespace test-namespace"))
+			})
 
-			Expect(metadata.ComputeMetadata(tk)).To(Succeed())
+			it("returns error if services are not available", func() {
+				Expect(metadata.ComputeMetadata(tk, nil, nil, nil)).To(MatchError("::error ::token must be set to verify owner"))
+			})
 		})
 	}, spec.Report(report.Terminal{}))
 }
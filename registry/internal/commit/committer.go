@@ -0,0 +1,160 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/go-github/v89/github"
+
+	"github.com/buildpacks/github-actions/registry/internal/services"
+)
+
+// ErrConflict is returned when the file changed since it was read and the change must be recomputed.
+var ErrConflict = errors.New("conflict")
+
+type Committer struct {
+	Config
+	Git          services.GitService
+	PullRequests services.PullRequestsService
+	Repositories services.RepositoriesService
+}
+
+// File is the new content of the file at Path.  SHA is the blob SHA of the content the change was computed from and
+// is nil for new files.
+type File struct {
+	Path    string
+	Content []byte
+	SHA     *string
+}
+
+// Commit writes file to the default branch with the Contents API or, when a signer is configured, with a signed commit
+// created through the Git Data API.
+func (c Committer) Commit(ctx context.Context, owner string, repository string, file File, message string) error {
+	return c.commit(ctx, owner, repository, "", file, message)
+}
+
+// commit writes file to branch, or to the default branch if branch is empty.
+func (c Committer) commit(ctx context.Context, owner string, repository string, branch string, file File, message string) error {
+	if c.Signer != nil {
+		return c.commitSigned(ctx, owner, repository, branch, file, message)
+	}
+
+	var b *string
+	if branch != "" {
+		b = github.Ptr(branch)
+	}
+
+	author := c.Author
+	_, resp, err := c.Repositories.CreateFile(ctx, owner, repository, file.Path, &github.RepositoryContentFileOptions{
+		Author:    &author,
+		Committer: c.Committer,
+		Message:   github.Ptr(message),
+		SHA:       file.SHA,
+		Content:   file.Content,
+		Branch:    b,
+	})
+	if resp != nil && resp.StatusCode == http.StatusConflict {
+		return ErrConflict
+	}
+
+	return err
+}
+
+func (c Committer) commitSigned(ctx context.Context, owner string, repository string, branch string, file File, message string) error {
+	if branch == "" {
+		r, _, err := c.Repositories.Get(ctx, owner, repository)
+		if err != nil {
+			return fmt.Errorf("unable to get repository %s/%s\n%w", owner, repository, err)
+		}
+		branch = r.GetDefaultBranch()
+	}
+	ref := fmt.Sprintf("heads/%s", branch)
+
+	head, _, err := c.Git.GetRef(ctx, owner, repository, ref)
+	if err != nil {
+		return fmt.Errorf("unable to get %s\n%w", ref, err)
+	}
+	parent := head.GetObject().GetSHA()
+
+	current, _, resp, err := c.Repositories.GetContents(ctx, owner, repository, file.Path, &github.RepositoryContentGetOptions{Ref: parent})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		current = &github.RepositoryContent{}
+	} else if err != nil {
+		return fmt.Errorf("unable to read %s\n%w", file.Path, err)
+	}
+
+	expected := ""
+	if file.SHA != nil {
+		expected = *file.SHA
+	}
+
+	if current.GetSHA() != expected {
+		return ErrConflict
+	}
+
+	pc, _, err := c.Git.GetCommit(ctx, owner, repository, parent)
+	if err != nil {
+		return fmt.Errorf("unable to get commit %s\n%w", parent, err)
+	}
+
+	tree, _, err := c.Git.CreateTree(ctx, owner, repository, pc.GetTree().GetSHA(), []*github.TreeEntry{
+		{
+			Path:    github.Ptr(file.Path),
+			Mode:    github.Ptr("100644"),
+			Type:    github.Ptr("blob"),
+			Content: github.Ptr(string(file.Content)),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("unable to create tree\n%w", err)
+	}
+
+	now := &github.Timestamp{Time: time.Now().UTC().Truncate(time.Second)}
+
+	author := c.Author
+	author.Date = now
+
+	committer := author
+	if c.Committer != nil {
+		committer = *c.Committer
+		committer.Date = now
+	}
+
+	commit, _, err := c.Git.CreateCommit(ctx, owner, repository, github.Commit{
+		Author:    &author,
+		Committer: &committer,
+		Message:   github.Ptr(message),
+		Tree:      &github.Tree{SHA: tree.SHA},
+		Parents:   []*github.Commit{{SHA: github.Ptr(parent)}},
+	}, &github.CreateCommitOptions{Signer: c.Signer})
+	if err != nil {
+		return fmt.Errorf("unable to create commit\n%w", err)
+	}
+
+	if _, resp, err := c.Git.UpdateRef(ctx, owner, repository, ref, github.UpdateRef{SHA: commit.GetSHA()}); resp != nil && resp.StatusCode == http.StatusUnprocessableEntity {
+		return ErrConflict
+	} else if err != nil {
+		return fmt.Errorf("unable to update %s\n%w", ref, err)
+	}
+
+	return nil
+}
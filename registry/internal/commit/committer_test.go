@@ -0,0 +1,149 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commit_test
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v89/github"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/buildpacks/github-actions/registry/internal/commit"
+	"github.com/buildpacks/github-actions/registry/internal/services"
+)
+
+func TestCommitter(t *testing.T) {
+	spec.Run(t, "committer", func(t *testing.T, context spec.G, it spec.S) {
+		var (
+			Expect = NewWithT(t).Expect
+
+			g = &services.MockGitService{}
+			r = &services.MockRepositoriesService{}
+
+			author = github.CommitAuthor{Name: github.Ptr("test-name"), Email: github.Ptr("test-email")}
+			file   = commit.File{Path: "test-path", Content: []byte("test-content"), SHA: github.Ptr("test-sha")}
+		)
+
+		context("unsigned", func() {
+			var committer commit.Committer
+
+			it.Before(func() {
+				committer = commit.Committer{Config: commit.Config{Author: author}, Git: g, Repositories: r}
+			})
+
+			it("commits with contents API", func() {
+				r.On("CreateFile", mock.Anything, "test-owner", "test-repository", "test-path", &github.RepositoryContentFileOptions{
+					Author:  &author,
+					Message: github.Ptr("test-message"),
+					SHA:     github.Ptr("test-sha"),
+					Content: []byte("test-content"),
+				}).Return(nil, nil, nil)
+
+				Expect(committer.Commit(t.Context(), "test-owner", "test-repository", file, "test-message")).To(Succeed())
+			})
+
+			it("returns conflict", func() {
+				r.On("CreateFile", mock.Anything, "test-owner", "test-repository", "test-path", mock.Anything).
+					Return(nil, &github.Response{Response: &http.Response{StatusCode: http.StatusConflict}}, nil)
+
+				Expect(committer.Commit(t.Context(), "test-owner", "test-repository", file, "test-message")).
+					To(MatchError(commit.ErrConflict))
+			})
+		})
+
+		context("signed", func() {
+			var committer commit.Committer
+
+			it.Before(func() {
+				committer = commit.Committer{
+					Config: commit.Config{
+						Author: author,
+						Signer: github.MessageSignerFunc(func(w io.Writer, r io.Reader) error {
+							_, err := w.Write([]byte("test-signature"))
+							return err
+						}),
+					},
+					Git:          g,
+					Repositories: r,
+				}
+
+				r.On("Get", mock.Anything, "test-owner", "test-repository").
+					Return(&github.Repository{DefaultBranch: github.Ptr("main")}, nil, nil)
+				g.On("GetRef", mock.Anything, "test-owner", "test-repository", "heads/main").
+					Return(&github.Reference{Object: &github.GitObject{SHA: github.Ptr("test-parent")}}, nil, nil)
+			})
+
+			it("commits with Git Data API", func() {
+				r.On("GetContents", mock.Anything, "test-owner", "test-repository", "test-path", &github.RepositoryContentGetOptions{Ref: "test-parent"}).
+					Return(&github.RepositoryContent{SHA: github.Ptr("test-sha")}, nil, nil, nil)
+				g.On("GetCommit", mock.Anything, "test-owner", "test-repository", "test-parent").
+					Return(&github.Commit{Tree: &github.Tree{SHA: github.Ptr("test-base-tree")}}, nil, nil)
+				g.On("CreateTree", mock.Anything, "test-owner", "test-repository", "test-base-tree", []*github.TreeEntry{
+					{
+						Path:    github.Ptr("test-path"),
+						Mode:    github.Ptr("100644"),
+						Type:    github.Ptr("blob"),
+						Content: github.Ptr("test-content"),
+					},
+				}).Return(&github.Tree{SHA: github.Ptr("test-tree")}, nil, nil)
+				g.On("CreateCommit", mock.Anything, "test-owner", "test-repository", mock.MatchedBy(func(c github.Commit) bool {
+					return c.GetMessage() == "test-message" &&
+						c.GetAuthor().GetName() == "test-name" &&
+						c.GetAuthor().Date != nil &&
+						c.GetCommitter().GetName() == "test-name" &&
+						c.GetTree().GetSHA() == "test-tree" &&
+						len(c.Parents) == 1 && c.Parents[0].GetSHA() == "test-parent"
+				}), mock.MatchedBy(func(o *github.CreateCommitOptions) bool {
+					return o.Signer != nil
+				})).Return(&github.Commit{SHA: github.Ptr("test-commit")}, nil, nil)
+				g.On("UpdateRef", mock.Anything, "test-owner", "test-repository", "heads/main", github.UpdateRef{SHA: "test-commit"}).
+					Return(&github.Reference{}, nil, nil)
+
+				Expect(committer.Commit(t.Context(), "test-owner", "test-repository", file, "test-message")).To(Succeed())
+			})
+
+			it("returns conflict if file changed", func() {
+				r.On("GetContents", mock.Anything, "test-owner", "test-repository", "test-path", &github.RepositoryContentGetOptions{Ref: "test-parent"}).
+					Return(&github.RepositoryContent{SHA: github.Ptr("another-sha")}, nil, nil, nil)
+
+				Expect(committer.Commit(t.Context(), "test-owner", "test-repository", file, "test-message")).
+					To(MatchError(commit.ErrConflict))
+			})
+
+			it("returns conflict if branch moved", func() {
+				r.On("GetContents", mock.Anything, "test-owner", "test-repository", "test-path", &github.RepositoryContentGetOptions{Ref: "test-parent"}).
+					Return(&github.RepositoryContent{SHA: github.Ptr("test-sha")}, nil, nil, nil)
+				g.On("GetCommit", mock.Anything, "test-owner", "test-repository", "test-parent").
+					Return(&github.Commit{Tree: &github.Tree{SHA: github.Ptr("test-base-tree")}}, nil, nil)
+				g.On("CreateTree", mock.Anything, "test-owner", "test-repository", "test-base-tree", mock.Anything).
+					Return(&github.Tree{SHA: github.Ptr("test-tree")}, nil, nil)
+				g.On("CreateCommit", mock.Anything, "test-owner", "test-repository", mock.Anything, mock.Anything).
+					Return(&github.Commit{SHA: github.Ptr("test-commit")}, nil, nil)
+				g.On("UpdateRef", mock.Anything, "test-owner", "test-repository", "heads/main", mock.Anything).
+					Return(nil, &github.Response{Response: &http.Response{StatusCode: http.StatusUnprocessableEntity}}, &github.ErrorResponse{})
+
+				Expect(committer.Commit(t.Context(), "test-owner", "test-repository", file, "test-message")).
+					To(MatchError(commit.ErrConflict))
+			})
+		})
+	}, spec.Report(report.Terminal{}))
+}
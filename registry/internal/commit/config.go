@@ -0,0 +1,102 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commit
+
+import (
+	"strings"
+
+	"github.com/google/go-github/v89/github"
+
+	"github.com/buildpacks/github-actions/internal/toolkit"
+)
+
+const (
+	DefaultName  = "buildpacks-bot"
+	DefaultEmail = "cncf-buildpacks-maintainers@lists.cncf.io"
+)
+
+type Config struct {
+	Author    github.CommitAuthor
+	Committer *github.CommitAuthor
+	Message   string
+	Signer    github.MessageSigner
+}
+
+// Values are substituted for the {ns}, {name}, {version} and {url} placeholders of a commit message template.
+type Values struct {
+	Namespace string
+	Name      string
+	Version   string
+	URL       string
+}
+
+func ParseConfig(tk toolkit.Toolkit, message string) (Config, error) {
+	c := Config{
+		Author: github.CommitAuthor{
+			Name:  github.Ptr(DefaultName),
+			Email: github.Ptr(DefaultEmail),
+		},
+		Message: message,
+	}
+
+	if s, ok := tk.GetInput("author-name"); ok && s != "" {
+		c.Author.Name = github.Ptr(s)
+	}
+
+	if s, ok := tk.GetInput("author-email"); ok && s != "" {
+		c.Author.Email = github.Ptr(s)
+	}
+
+	name, nameOk := tk.GetInput("committer-name")
+	email, emailOk := tk.GetInput("committer-email")
+	if (nameOk && name != "") || (emailOk && email != "") {
+		c.Committer = &github.CommitAuthor{Name: c.Author.Name, Email: c.Author.Email}
+
+		if name != "" {
+			c.Committer.Name = github.Ptr(name)
+		}
+
+		if email != "" {
+			c.Committer.Email = github.Ptr(email)
+		}
+	}
+
+	if s, ok := tk.GetInput("commit-message"); ok && s != "" {
+		c.Message = s
+	}
+
+	if s, ok := tk.GetInput("signing-key"); ok && s != "" {
+		p, _ := tk.GetInput("signing-key-passphrase")
+
+		signer, err := NewSigner([]byte(s), []byte(p))
+		if err != nil {
+			return Config{}, toolkit.FailedErrorf("unable to parse signing-key\n%w", err)
+		}
+		c.Signer = signer
+	}
+
+	return c, nil
+}
+
+func (c Config) FormatMessage(v Values) string {
+	return strings.NewReplacer(
+		"{ns}", v.Namespace,
+		"{name}", v.Name,
+		"{version}", v.Version,
+		"{url}", v.URL,
+	).Replace(c.Message)
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commit_test
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v89/github"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/github-actions/internal/toolkit"
+	"github.com/buildpacks/github-actions/registry/internal/commit"
+)
+
+func TestConfig(t *testing.T) {
+	spec.Run(t, "config", func(t *testing.T, context spec.G, it spec.S) {
+		var (
+			Expect = NewWithT(t).Expect
+
+			tk = &toolkit.MockToolkit{}
+		)
+
+		it("uses defaults", func() {
+			tk.On("GetInput", "author-name").Return("", false)
+			tk.On("GetInput", "author-email").Return("", false)
+			tk.On("GetInput", "committer-name").Return("", false)
+			tk.On("GetInput", "committer-email").Return("", false)
+			tk.On("GetInput", "commit-message").Return("", false)
+			tk.On("GetInput", "signing-key").Return("", false)
+
+			c, err := commit.ParseConfig(tk, "ADD {ns}/{name}@{version}")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c).To(Equal(commit.Config{
+				Author: github.CommitAuthor{
+					Name:  github.Ptr(commit.DefaultName),
+					Email: github.Ptr(commit.DefaultEmail),
+				},
+				Message: "ADD {ns}/{name}@{version}",
+			}))
+		})
+
+		it("uses configured identities and message", func() {
+			tk.On("GetInput", "author-name").Return("test-author", true)
+			tk.On("GetInput", "author-email").Return("test-author@example.com", true)
+			tk.On("GetInput", "committer-name").Return("", false)
+			tk.On("GetInput", "committer-email").Return("test-committer@example.com", true)
+			tk.On("GetInput", "commit-message").Return("Add {ns}/{name} {version}\n\n{url}", true)
+			tk.On("GetInput", "signing-key").Return("", false)
+
+			c, err := commit.ParseConfig(tk, "ADD {ns}/{name}@{version}")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(c.Author).To(Equal(github.CommitAuthor{
+				Name:  github.Ptr("test-author"),
+				Email: github.Ptr("test-author@example.com"),
+			}))
+			Expect(c.Committer).To(Equal(&github.CommitAuthor{
+				Name:  github.Ptr("test-author"),
+				Email: github.Ptr("test-committer@example.com"),
+			}))
+			Expect(c.FormatMessage(commit.Values{
+				Namespace: "test-namespace",
+				Name:      "test-name",
+				Version:   "test-version",
+				URL:       "test-url",
+			})).To(Equal("Add test-namespace/test-name test-version\n\ntest-url"))
+		})
+
+		it("fails if signing key is invalid", func() {
+			tk.On("GetInput", "author-name").Return("", false)
+			tk.On("GetInput", "author-email").Return("", false)
+			tk.On("GetInput", "committer-name").Return("", false)
+			tk.On("GetInput", "committer-email").Return("", false)
+			tk.On("GetInput", "commit-message").Return("", false)
+			tk.On("GetInput", "signing-key").Return("test-key", true)
+			tk.On("GetInput", "signing-key-passphrase").Return("", false)
+
+			_, err := commit.ParseConfig(tk, "ADD {ns}/{name}@{version}")
+			Expect(err).To(MatchError(ContainSubstring("unable to parse signing-key")))
+		})
+	}, spec.Report(report.Terminal{}))
+}
@@ -0,0 +1,163 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/go-github/v89/github"
+	"gopkg.in/retry.v1"
+
+	"github.com/buildpacks/github-actions/internal/toolkit"
+	"github.com/buildpacks/github-actions/registry/internal/services"
+)
+
+const DefaultMergeTimeout = 20 * time.Minute
+
+type PullRequestConfig struct {
+	Enabled bool
+	Wait    bool
+	Timeout time.Duration
+}
+
+type PullRequest struct {
+	Branch string
+	Title  string
+	Body   string
+}
+
+func ParsePullRequestConfig(tk toolkit.Toolkit) (PullRequestConfig, error) {
+	c := PullRequestConfig{Timeout: DefaultMergeTimeout}
+
+	if s, ok := tk.GetInput("pull-request"); ok {
+		if t, err := strconv.ParseBool(s); err == nil {
+			c.Enabled = t
+		}
+	}
+
+	if s, ok := tk.GetInput("wait-for-merge"); ok {
+		if t, err := strconv.ParseBool(s); err == nil {
+			c.Wait = t
+		}
+	}
+
+	if s, ok := tk.GetInput("merge-timeout"); ok && s != "" {
+		t, err := time.ParseDuration(s)
+		if err != nil {
+			return PullRequestConfig{}, toolkit.FailedErrorf("invalid merge-timeout %s", s)
+		}
+		c.Timeout = t
+	}
+
+	return c, nil
+}
+
+func (p PullRequestConfig) Strategy() retry.Strategy {
+	return retry.LimitTime(
+		p.Timeout,
+		retry.Exponential{
+			Initial:  time.Second,
+			MaxDelay: 30 * time.Second,
+		},
+	)
+}
+
+// Propose writes file to pr.Branch, reset to the head of the default branch, and opens a pull request for it or
+// updates the pull request already open for that branch.
+func (c Committer) Propose(ctx context.Context, owner string, repository string, file File, message string, pr PullRequest) (*github.PullRequest, error) {
+	r, _, err := c.Repositories.Get(ctx, owner, repository)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get repository %s/%s\n%w", owner, repository, err)
+	}
+	base := r.GetDefaultBranch()
+
+	head, _, err := c.Git.GetRef(ctx, owner, repository, fmt.Sprintf("heads/%s", base))
+	if err != nil {
+		return nil, fmt.Errorf("unable to get heads/%s\n%w", base, err)
+	}
+	sha := head.GetObject().GetSHA()
+
+	ref := fmt.Sprintf("heads/%s", pr.Branch)
+	if _, resp, err := c.Git.GetRef(ctx, owner, repository, ref); resp != nil && resp.StatusCode == http.StatusNotFound {
+		if _, _, err := c.Git.CreateRef(ctx, owner, repository, github.CreateRef{Ref: fmt.Sprintf("refs/%s", ref), SHA: sha}); err != nil {
+			return nil, fmt.Errorf("unable to create %s\n%w", ref, err)
+		}
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to get %s\n%w", ref, err)
+	} else if _, _, err := c.Git.UpdateRef(ctx, owner, repository, ref, github.UpdateRef{SHA: sha, Force: github.Ptr(true)}); err != nil {
+		return nil, fmt.Errorf("unable to reset %s\n%w", ref, err)
+	}
+
+	if err := c.commit(ctx, owner, repository, pr.Branch, file, message); err != nil {
+		return nil, err
+	}
+
+	pulls, _, err := c.PullRequests.List(ctx, owner, repository, &github.PullRequestListOptions{
+		State: "open",
+		Head:  fmt.Sprintf("%s:%s", owner, pr.Branch),
+		Base:  base,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list pull requests\n%w", err)
+	}
+
+	if len(pulls) > 0 {
+		p, _, err := c.PullRequests.Edit(ctx, owner, repository, pulls[0].GetNumber(), &github.PullRequest{
+			Title: github.Ptr(pr.Title),
+			Body:  github.Ptr(pr.Body),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("unable to update pull request %s\n%w", pulls[0].GetHTMLURL(), err)
+		}
+		return p, nil
+	}
+
+	p, _, err := c.PullRequests.Create(ctx, owner, repository, &github.NewPullRequest{
+		Title: github.Ptr(pr.Title),
+		Head:  github.Ptr(pr.Branch),
+		Base:  github.Ptr(base),
+		Body:  github.Ptr(pr.Body),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to create pull request\n%w", err)
+	}
+
+	return p, nil
+}
+
+func WaitForMerge(owner string, repository string, number int, url string, tk toolkit.Toolkit, pulls services.PullRequestsService, strategy retry.Strategy) error {
+	for a := retry.Start(strategy, nil); a.Next(); {
+		pr, _, err := pulls.Get(context.Background(), owner, repository, number)
+		if err != nil {
+			tk.Warningf("unable to get state for %s", url)
+			continue
+		}
+
+		if pr.GetMerged() {
+			fmt.Printf("Pull request %s merged\n", url)
+			return nil
+		} else if pr.GetState() == "closed" {
+			return toolkit.FailedErrorf("pull request %s was closed without merging", url)
+		}
+	}
+
+	return toolkit.FailedError("timed out waiting for pull request to be merged")
+}
@@ -0,0 +1,147 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commit_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v89/github"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/stretchr/testify/mock"
+	"gopkg.in/retry.v1"
+
+	"github.com/buildpacks/github-actions/internal/toolkit"
+	"github.com/buildpacks/github-actions/registry/internal/commit"
+	"github.com/buildpacks/github-actions/registry/internal/services"
+)
+
+func TestPullRequest(t *testing.T) {
+	spec.Run(t, "pull-request", func(t *testing.T, context spec.G, it spec.S) {
+		var (
+			Expect = NewWithT(t).Expect
+
+			g  = &services.MockGitService{}
+			p  = &services.MockPullRequestsService{}
+			r  = &services.MockRepositoriesService{}
+			s  = retry.LimitCount(2, retry.Regular{Min: 2})
+			tk = &toolkit.MockToolkit{}
+
+			committer commit.Committer
+			file      = commit.File{Path: "test-path", Content: []byte("test-content"), SHA: github.Ptr("test-sha")}
+			pr        = commit.PullRequest{Branch: "test-branch", Title: "test-title", Body: "test-body"}
+		)
+
+		it.Before(func() {
+			committer = commit.Committer{
+				Config:       commit.Config{Author: github.CommitAuthor{Name: github.Ptr("test-name")}},
+				Git:          g,
+				PullRequests: p,
+				Repositories: r,
+			}
+		})
+
+		it("parses pull request config", func() {
+			tk.On("GetInput", "pull-request").Return("true", true)
+			tk.On("GetInput", "wait-for-merge").Return("true", true)
+			tk.On("GetInput", "merge-timeout").Return("1h", true)
+
+			Expect(commit.ParsePullRequestConfig(tk)).To(Equal(commit.PullRequestConfig{
+				Enabled: true,
+				Wait:    true,
+				Timeout: time.Hour,
+			}))
+		})
+
+		context("propose", func() {
+			it.Before(func() {
+				r.On("Get", mock.Anything, "test-owner", "test-repository").
+					Return(&github.Repository{DefaultBranch: github.Ptr("main")}, nil, nil)
+				g.On("GetRef", mock.Anything, "test-owner", "test-repository", "heads/main").
+					Return(&github.Reference{Object: &github.GitObject{SHA: github.Ptr("test-head")}}, nil, nil)
+				r.On("CreateFile", mock.Anything, "test-owner", "test-repository", "test-path", mock.MatchedBy(func(o *github.RepositoryContentFileOptions) bool {
+					return o.GetBranch() == "test-branch" && o.GetSHA() == "test-sha"
+				})).Return(nil, nil, nil)
+			})
+
+			it("creates branch and pull request", func() {
+				g.On("GetRef", mock.Anything, "test-owner", "test-repository", "heads/test-branch").
+					Return(nil, &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, &github.ErrorResponse{})
+				g.On("CreateRef", mock.Anything, "test-owner", "test-repository", github.CreateRef{Ref: "refs/heads/test-branch", SHA: "test-head"}).
+					Return(&github.Reference{}, nil, nil)
+				p.On("List", mock.Anything, "test-owner", "test-repository", mock.Anything).
+					Return([]*github.PullRequest{}, nil, nil)
+				p.On("Create", mock.Anything, "test-owner", "test-repository", &github.NewPullRequest{
+					Title: github.Ptr("test-title"),
+					Head:  github.Ptr("test-branch"),
+					Base:  github.Ptr("main"),
+					Body:  github.Ptr("test-body"),
+				}).Return(&github.PullRequest{Number: github.Ptr(1)}, nil, nil)
+
+				Expect(committer.Propose(t.Context(), "test-owner", "test-repository", file, "test-message", pr)).
+					To(Equal(&github.PullRequest{Number: github.Ptr(1)}))
+			})
+
+			it("resets branch and updates existing pull request", func() {
+				g.On("GetRef", mock.Anything, "test-owner", "test-repository", "heads/test-branch").
+					Return(&github.Reference{}, nil, nil)
+				g.On("UpdateRef", mock.Anything, "test-owner", "test-repository", "heads/test-branch", github.UpdateRef{SHA: "test-head", Force: github.Ptr(true)}).
+					Return(&github.Reference{}, nil, nil)
+				p.On("List", mock.Anything, "test-owner", "test-repository", mock.Anything).
+					Return([]*github.PullRequest{{Number: github.Ptr(2)}}, nil, nil)
+				p.On("Edit", mock.Anything, "test-owner", "test-repository", 2, &github.PullRequest{
+					Title: github.Ptr("test-title"),
+					Body:  github.Ptr("test-body"),
+				}).Return(&github.PullRequest{Number: github.Ptr(2)}, nil, nil)
+
+				Expect(committer.Propose(t.Context(), "test-owner", "test-repository", file, "test-message", pr)).
+					To(Equal(&github.PullRequest{Number: github.Ptr(2)}))
+			})
+		})
+
+		context("wait for merge", func() {
+			it("handles merge", func() {
+				p.On("Get", mock.Anything, "test-owner", "test-repository", 1).
+					Return(&github.PullRequest{State: github.Ptr("open")}, nil, nil).
+					Once()
+				p.On("Get", mock.Anything, "test-owner", "test-repository", 1).
+					Return(&github.PullRequest{State: github.Ptr("closed"), Merged: github.Ptr(true)}, nil, nil)
+
+				Expect(commit.WaitForMerge("test-owner", "test-repository", 1, "test-url", tk, p, s)).To(Succeed())
+			})
+
+			it("handles close without merge", func() {
+				p.On("Get", mock.Anything, "test-owner", "test-repository", 1).
+					Return(&github.PullRequest{State: github.Ptr("closed"), Merged: github.Ptr(false)}, nil, nil)
+
+				Expect(commit.WaitForMerge("test-owner", "test-repository", 1, "test-url", tk, p, s)).
+					To(MatchError("::error ::pull request test-url was closed without merging"))
+			})
+
+			it("times out", func() {
+				p.On("Get", mock.Anything, "test-owner", "test-repository", 1).
+					Return(&github.PullRequest{State: github.Ptr("open")}, nil, nil)
+
+				Expect(commit.WaitForMerge("test-owner", "test-repository", 1, "test-url", tk, p, s)).
+					To(MatchError("::error ::timed out waiting for pull request to be merged"))
+			})
+		})
+	}, spec.Report(report.Terminal{}))
+}
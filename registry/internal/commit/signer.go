@@ -0,0 +1,151 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commit
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/google/go-github/v89/github"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	sshSignatureMagic     = "SSHSIG"
+	sshSignatureNamespace = "git"
+	sshSignatureHash      = "sha512"
+)
+
+// NewSigner creates a commit signer from an armored GPG private key or an OpenSSH private key.
+func NewSigner(key []byte, passphrase []byte) (github.MessageSigner, error) {
+	switch {
+	case bytes.Contains(key, []byte("BEGIN PGP PRIVATE KEY BLOCK")):
+		return NewGPGSigner(key, passphrase)
+	case bytes.Contains(key, []byte("PRIVATE KEY")):
+		return NewSSHSigner(key, passphrase)
+	default:
+		return nil, fmt.Errorf("signing key must be an armored GPG private key or an OpenSSH private key")
+	}
+}
+
+func NewGPGSigner(key []byte, passphrase []byte) (github.MessageSigner, error) {
+	entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(key))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read GPG key\n%w", err)
+	}
+
+	if len(entities) == 0 || entities[0].PrivateKey == nil {
+		return nil, fmt.Errorf("GPG key does not contain a private key")
+	}
+	entity := entities[0]
+
+	if len(passphrase) > 0 {
+		if err := entity.DecryptPrivateKeys(passphrase); err != nil {
+			return nil, fmt.Errorf("unable to decrypt GPG key\n%w", err)
+		}
+	}
+
+	return github.MessageSignerFunc(func(w io.Writer, r io.Reader) error {
+		return openpgp.ArmoredDetachSign(w, entity, r, nil)
+	}), nil
+}
+
+func NewSSHSigner(key []byte, passphrase []byte) (github.MessageSigner, error) {
+	var (
+		signer ssh.Signer
+		err    error
+	)
+
+	if len(passphrase) > 0 {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase(key, passphrase)
+	} else {
+		signer, err = ssh.ParsePrivateKey(key)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse SSH key\n%w", err)
+	}
+
+	return github.MessageSignerFunc(func(w io.Writer, r io.Reader) error {
+		return sshSign(w, signer, r)
+	}), nil
+}
+
+// sshSign writes an armored signature in the format git produces with gpg.format=ssh.  See
+// https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.sshsig.
+func sshSign(w io.Writer, signer ssh.Signer, r io.Reader) error {
+	h := sha512.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return err
+	}
+
+	signed := &bytes.Buffer{}
+	signed.WriteString(sshSignatureMagic)
+	writeSSHString(signed, []byte(sshSignatureNamespace))
+	writeSSHString(signed, nil)
+	writeSSHString(signed, []byte(sshSignatureHash))
+	writeSSHString(signed, h.Sum(nil))
+
+	var (
+		signature *ssh.Signature
+		err       error
+	)
+	if a, ok := signer.(ssh.AlgorithmSigner); ok && signer.PublicKey().Type() == ssh.KeyAlgoRSA {
+		signature, err = a.SignWithAlgorithm(rand.Reader, signed.Bytes(), ssh.KeyAlgoRSASHA512)
+	} else {
+		signature, err = signer.Sign(rand.Reader, signed.Bytes())
+	}
+	if err != nil {
+		return fmt.Errorf("unable to sign commit\n%w", err)
+	}
+
+	blob := &bytes.Buffer{}
+	blob.WriteString(sshSignatureMagic)
+	_ = binary.Write(blob, binary.BigEndian, uint32(1))
+	writeSSHString(blob, signer.PublicKey().Marshal())
+	writeSSHString(blob, []byte(sshSignatureNamespace))
+	writeSSHString(blob, nil)
+	writeSSHString(blob, []byte(sshSignatureHash))
+	writeSSHString(blob, ssh.Marshal(signature))
+
+	encoded := base64.StdEncoding.EncodeToString(blob.Bytes())
+
+	if _, err := fmt.Fprintln(w, "-----BEGIN SSH SIGNATURE-----"); err != nil {
+		return err
+	}
+	for len(encoded) > 70 {
+		if _, err := fmt.Fprintln(w, encoded[:70]); err != nil {
+			return err
+		}
+		encoded = encoded[70:]
+	}
+	if _, err := fmt.Fprintln(w, encoded); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(w, "-----END SSH SIGNATURE-----")
+	return err
+}
+
+func writeSSHString(b *bytes.Buffer, s []byte) {
+	_ = binary.Write(b, binary.BigEndian, uint32(len(s)))
+	b.Write(s)
+}
@@ -0,0 +1,125 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package commit_test
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/buildpacks/github-actions/registry/internal/commit"
+)
+
+func TestSigner(t *testing.T) {
+	spec.Run(t, "signer", func(t *testing.T, context spec.G, it spec.S) {
+		var (
+			Expect = NewWithT(t).Expect
+		)
+
+		it("signs with GPG key", func() {
+			e, err := openpgp.NewEntity("test-name", "", "test@example.com", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			key := &bytes.Buffer{}
+			w, err := armor.Encode(key, openpgp.PrivateKeyType, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(e.SerializePrivate(w, nil)).To(Succeed())
+			Expect(w.Close()).To(Succeed())
+
+			signer, err := commit.NewSigner(key.Bytes(), nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			signature := &bytes.Buffer{}
+			Expect(signer.Sign(signature, strings.NewReader("test-message"))).To(Succeed())
+
+			_, err = openpgp.CheckArmoredDetachedSignature(openpgp.EntityList{e}, strings.NewReader("test-message"), signature, nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		it("signs with SSH key", func() {
+			public, private, err := ed25519.GenerateKey(rand.Reader)
+			Expect(err).NotTo(HaveOccurred())
+
+			block, err := ssh.MarshalPrivateKey(private, "")
+			Expect(err).NotTo(HaveOccurred())
+
+			signer, err := commit.NewSigner(pem.EncodeToMemory(block), nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			signature := &bytes.Buffer{}
+			Expect(signer.Sign(signature, strings.NewReader("test-message"))).To(Succeed())
+
+			s := signature.String()
+			Expect(s).To(HavePrefix("-----BEGIN SSH SIGNATURE-----\n"))
+			Expect(s).To(HaveSuffix("-----END SSH SIGNATURE-----\n"))
+
+			lines := strings.Split(strings.TrimSpace(s), "\n")
+			blob, err := base64.StdEncoding.DecodeString(strings.Join(lines[1:len(lines)-1], ""))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(blob[:6])).To(Equal("SSHSIG"))
+
+			fields := readSSHStrings(blob[10:])
+			Expect(fields).To(HaveLen(5))
+
+			publicKey, err := ssh.NewPublicKey(public)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fields[0]).To(Equal(publicKey.Marshal()))
+			Expect(string(fields[1])).To(Equal("git"))
+			Expect(string(fields[3])).To(Equal("sha512"))
+
+			var sig ssh.Signature
+			Expect(ssh.Unmarshal(fields[4], &sig)).To(Succeed())
+
+			digest := sha512.Sum512([]byte("test-message"))
+			signed := &bytes.Buffer{}
+			signed.WriteString("SSHSIG")
+			for _, f := range [][]byte{[]byte("git"), nil, []byte("sha512"), digest[:]} {
+				Expect(binary.Write(signed, binary.BigEndian, uint32(len(f)))).To(Succeed())
+				signed.Write(f)
+			}
+			Expect(publicKey.Verify(signed.Bytes(), &sig)).To(Succeed())
+		})
+
+		it("fails with unknown key", func() {
+			_, err := commit.NewSigner([]byte("test-key"), nil)
+			Expect(err).To(MatchError("signing key must be an armored GPG private key or an OpenSSH private key"))
+		})
+	}, spec.Report(report.Terminal{}))
+}
+
+func readSSHStrings(b []byte) [][]byte {
+	var s [][]byte
+	for len(b) >= 4 {
+		n := binary.BigEndian.Uint32(b)
+		s = append(s, b[4:4+n])
+		b = b[4+n:]
+	}
+	return s
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package credentials
+
+import (
+	"github.com/google/go-github/v89/github"
+
+	"github.com/buildpacks/github-actions/internal/toolkit"
+)
+
+// NewClient creates a GitHub client authenticated with the token input or, when that is not set, with an installation
+// token for the owner/repository inputs minted by the GitHub App identified by the app-id and private-key inputs.  The
+// installation token also grants access to the namespaces-repository input, which must then have the same owner.
+func NewClient(tk toolkit.Toolkit) (*github.Client, error) {
+	if t, ok := tk.GetInput("token"); ok && t != "" {
+		return github.NewClient(github.WithAuthToken(t))
+	}
+
+	appID, ok := tk.GetInput("app-id")
+	if !ok || appID == "" {
+		return nil, toolkit.FailedError("token or app-id and private-key must be specified")
+	}
+
+	privateKey, ok := tk.GetInput("private-key")
+	if !ok || privateKey == "" {
+		return nil, toolkit.FailedError("private-key must be specified with app-id")
+	}
+
+	owner, ok := tk.GetInput("owner")
+	if !ok {
+		return nil, toolkit.FailedError("owner must be set")
+	}
+
+	repository, ok := tk.GetInput("repository")
+	if !ok {
+		return nil, toolkit.FailedError("repository must be set")
+	}
+
+	key, err := ParsePrivateKey([]byte(privateKey))
+	if err != nil {
+		return nil, toolkit.FailedErrorf("unable to parse private-key\n%w", err)
+	}
+
+	var repositories []string
+	if s, ok := tk.GetInput("namespaces-owner"); ok && s != "" && s != owner {
+		return nil, toolkit.FailedErrorf("namespaces-owner %s must be owner %s with app-id, installation tokens only grant access to repositories of one owner", s, owner)
+	}
+	if s, ok := tk.GetInput("namespaces-repository"); ok && s != "" && s != repository {
+		repositories = append(repositories, s)
+	}
+
+	app, err := github.NewClient(github.WithTransport(&AppTransport{AppID: appID, Key: key}))
+	if err != nil {
+		return nil, err
+	}
+
+	return github.NewClient(github.WithTransport(&InstallationTransport{
+		Apps:       app.Apps,
+		Owner:      owner,
+		Repository: repository,
+		Toolkit:    tk,
+
+		Repositories: repositories,
+	}))
+}
@@ -0,0 +1,110 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package credentials_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/github-actions/internal/toolkit"
+	"github.com/buildpacks/github-actions/registry/internal/credentials"
+)
+
+func TestCredentials(t *testing.T) {
+	spec.Run(t, "credentials", func(t *testing.T, context spec.G, it spec.S) {
+		var (
+			Expect = NewWithT(t).Expect
+
+			tk = &toolkit.MockToolkit{}
+		)
+
+		it("creates client with token", func() {
+			tk.On("GetInput", "token").Return("test-token", true)
+
+			Expect(credentials.NewClient(tk)).NotTo(BeNil())
+		})
+
+		it("fails if neither token nor app-id is set", func() {
+			tk.On("GetInput", "token").Return("", false)
+			tk.On("GetInput", "app-id").Return("", false)
+
+			_, err := credentials.NewClient(tk)
+			Expect(err).To(MatchError("::error ::token or app-id and private-key must be specified"))
+		})
+
+		it("fails if private-key is not set", func() {
+			tk.On("GetInput", "token").Return("", false)
+			tk.On("GetInput", "app-id").Return("test-app-id", true)
+			tk.On("GetInput", "private-key").Return("", false)
+
+			_, err := credentials.NewClient(tk)
+			Expect(err).To(MatchError("::error ::private-key must be specified with app-id"))
+		})
+
+		it("fails if private-key is invalid", func() {
+			tk.On("GetInput", "token").Return("", false)
+			tk.On("GetInput", "app-id").Return("test-app-id", true)
+			tk.On("GetInput", "private-key").Return("test-private-key", true)
+			tk.On("GetInput", "owner").Return("test-owner", true)
+			tk.On("GetInput", "repository").Return("test-repository", true)
+
+			_, err := credentials.NewClient(tk)
+			Expect(err).To(MatchError(ContainSubstring("unable to parse private-key")))
+		})
+
+		context("app-id and private-key are set", func() {
+			it.Before(func() {
+				key, err := rsa.GenerateKey(rand.Reader, 2048)
+				Expect(err).NotTo(HaveOccurred())
+
+				tk.On("GetInput", "token").Return("", false)
+				tk.On("GetInput", "app-id").Return("test-app-id", true)
+				tk.On("GetInput", "private-key").
+					Return(string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})), true)
+				tk.On("GetInput", "owner").Return("test-owner", true)
+				tk.On("GetInput", "repository").Return("test-repository", true)
+			})
+
+			it("grants access to namespaces-repository", func() {
+				tk.On("GetInput", "namespaces-owner").Return("test-owner", true)
+				tk.On("GetInput", "namespaces-repository").Return("test-namespaces", true)
+
+				gh, err := credentials.NewClient(tk)
+				Expect(err).NotTo(HaveOccurred())
+
+				transport, ok := gh.Client().Transport.(*credentials.InstallationTransport)
+				Expect(ok).To(BeTrue())
+				Expect(transport.Repository).To(Equal("test-repository"))
+				Expect(transport.Repositories).To(Equal([]string{"test-namespaces"}))
+			})
+
+			it("fails if namespaces-owner is another owner", func() {
+				tk.On("GetInput", "namespaces-owner").Return("another-owner", true)
+
+				_, err := credentials.NewClient(tk)
+				Expect(err).To(MatchError("::error ::namespaces-owner another-owner must be owner test-owner with app-id, installation tokens only grant access to repositories of one owner"))
+			})
+		})
+	}, spec.Report(report.Terminal{}))
+}
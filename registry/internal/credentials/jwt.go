@@ -0,0 +1,91 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package credentials
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"time"
+)
+
+const (
+	// JWTClockSkew backdates the JWT issue time to tolerate clock drift between the runner and GitHub.
+	JWTClockSkew = time.Minute
+
+	// JWTLifetime is the lifetime of an app JWT.  GitHub rejects JWTs that expire more than ten minutes in the future.
+	JWTLifetime = 9 * time.Minute
+)
+
+// ParsePrivateKey parses a PEM encoded PKCS#1 or PKCS#8 RSA private key.
+func ParsePrivateKey(b []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(b)
+	if block == nil {
+		return nil, fmt.Errorf("private key is not PEM encoded")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	k, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse private key\n%w", err)
+	}
+
+	key, ok := k.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("private key must be an RSA key")
+	}
+
+	return key, nil
+}
+
+// NewJWT creates an RS256 signed JWT that authenticates as the GitHub App appID.
+func NewJWT(appID string, key *rsa.PrivateKey, now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(map[string]interface{}{
+		"iat": now.Add(-JWTClockSkew).Unix(),
+		"exp": now.Add(JWTLifetime).Unix(),
+		"iss": appID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := fmt.Sprintf("%s.%s",
+		base64.RawURLEncoding.EncodeToString(header),
+		base64.RawURLEncoding.EncodeToString(claims))
+
+	digest := sha256.Sum256([]byte(unsigned))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("unable to sign JWT\n%w", err)
+	}
+
+	return fmt.Sprintf("%s.%s", unsigned, base64.RawURLEncoding.EncodeToString(signature)), nil
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package credentials_test
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/github-actions/registry/internal/credentials"
+)
+
+func TestJWT(t *testing.T) {
+	spec.Run(t, "jwt", func(t *testing.T, context spec.G, it spec.S) {
+		var (
+			Expect = NewWithT(t).Expect
+
+			key *rsa.PrivateKey
+		)
+
+		it.Before(func() {
+			var err error
+			key, err = rsa.GenerateKey(rand.Reader, 2048)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		it("parses PKCS1 private keys", func() {
+			b := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+			Expect(credentials.ParsePrivateKey(b)).To(Equal(key))
+		})
+
+		it("parses PKCS8 private keys", func() {
+			der, err := x509.MarshalPKCS8PrivateKey(key)
+			Expect(err).NotTo(HaveOccurred())
+			b := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+
+			k, err := credentials.ParsePrivateKey(b)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(k.Equal(key)).To(BeTrue())
+		})
+
+		it("fails if private key is not PEM encoded", func() {
+			_, err := credentials.ParsePrivateKey([]byte("test-key"))
+			Expect(err).To(MatchError("private key is not PEM encoded"))
+		})
+
+		it("creates signed JWT", func() {
+			now := time.Unix(1_000_000, 0)
+
+			s, err := credentials.NewJWT("test-app-id", key, now)
+			Expect(err).NotTo(HaveOccurred())
+
+			parts := strings.Split(s, ".")
+			Expect(parts).To(HaveLen(3))
+
+			b, err := base64.RawURLEncoding.DecodeString(parts[1])
+			Expect(err).NotTo(HaveOccurred())
+
+			var claims map[string]interface{}
+			Expect(json.Unmarshal(b, &claims)).To(Succeed())
+			Expect(claims).To(Equal(map[string]interface{}{
+				"iat": float64(now.Add(-credentials.JWTClockSkew).Unix()),
+				"exp": float64(now.Add(credentials.JWTLifetime).Unix()),
+				"iss": "test-app-id",
+			}))
+
+			signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+			Expect(err).NotTo(HaveOccurred())
+
+			digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+			Expect(rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature)).To(Succeed())
+		})
+	}, spec.Report(report.Terminal{}))
+}
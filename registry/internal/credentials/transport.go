@@ -0,0 +1,126 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package credentials
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v89/github"
+
+	"github.com/buildpacks/github-actions/internal/toolkit"
+	"github.com/buildpacks/github-actions/registry/internal/services"
+)
+
+// TokenRefreshWindow is how long before its expiry an installation token is replaced.
+const TokenRefreshWindow = 5 * time.Minute
+
+type AppTransport struct {
+	AppID string
+	Key   *rsa.PrivateKey
+	Base  http.RoundTripper
+}
+
+func (a *AppTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t, err := NewJWT(a.AppID, a.Key, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t))
+	return base(a.Base).RoundTrip(req)
+}
+
+type InstallationTransport struct {
+	Apps       services.AppsService
+	Owner      string
+	Repository string
+	Toolkit    toolkit.Toolkit
+	Base       http.RoundTripper
+	Now        func() time.Time
+
+	// Repositories are other repositories of Owner the installation token grants access to.
+	Repositories []string
+
+	mutex        sync.Mutex
+	installation *int64
+	token        string
+	expiresAt    time.Time
+}
+
+func (i *InstallationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t, err := i.Token(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", t))
+	return base(i.Base).RoundTrip(req)
+}
+
+func (i *InstallationTransport) Token(ctx context.Context) (string, error) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	now := time.Now()
+	if i.Now != nil {
+		now = i.Now()
+	}
+
+	if i.token != "" && now.Add(TokenRefreshWindow).Before(i.expiresAt) {
+		return i.token, nil
+	}
+
+	if i.installation == nil {
+		installation, _, err := i.Apps.GetRepositoryInstallation(ctx, i.Owner, i.Repository)
+		if err != nil {
+			return "", fmt.Errorf("unable to find app installation for %s/%s\n%w", i.Owner, i.Repository, err)
+		}
+		i.installation = github.Ptr(installation.GetID())
+	}
+
+	t, _, err := i.Apps.CreateInstallationToken(ctx, *i.installation, &github.InstallationTokenOptions{
+		Repositories: append([]string{i.Repository}, i.Repositories...),
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to create installation token for %s/%s\n%w", i.Owner, i.Repository, err)
+	}
+
+	i.Toolkit.AddMask(t.GetToken())
+	if i.token != "" {
+		i.Toolkit.Debugf("refreshed installation token for %s/%s", i.Owner, i.Repository)
+	}
+
+	i.token = t.GetToken()
+	i.expiresAt = t.GetExpiresAt().Time
+
+	return i.token, nil
+}
+
+func base(rt http.RoundTripper) http.RoundTripper {
+	if rt == nil {
+		return http.DefaultTransport
+	}
+
+	return rt
+}
@@ -0,0 +1,147 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package credentials_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v89/github"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/buildpacks/github-actions/internal/toolkit"
+	"github.com/buildpacks/github-actions/registry/internal/credentials"
+	"github.com/buildpacks/github-actions/registry/internal/services"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (r roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return r(req)
+}
+
+func TestInstallationTransport(t *testing.T) {
+	spec.Run(t, "installation-transport", func(t *testing.T, context spec.G, it spec.S) {
+		var (
+			Expect = NewWithT(t).Expect
+
+			a   = &services.MockAppsService{}
+			tk  = &toolkit.MockToolkit{}
+			now = time.Unix(1_000_000, 0)
+
+			authorization string
+			transport     *credentials.InstallationTransport
+		)
+
+		it.Before(func() {
+			a.On("GetRepositoryInstallation", mock.Anything, "test-owner", "test-repository").
+				Return(&github.Installation{ID: github.Ptr(int64(1))}, nil, nil).
+				Once()
+
+			transport = &credentials.InstallationTransport{
+				Apps:       a,
+				Owner:      "test-owner",
+				Repository: "test-repository",
+				Toolkit:    tk,
+				Base: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					authorization = req.Header.Get("Authorization")
+					return &http.Response{StatusCode: http.StatusOK}, nil
+				}),
+				Now: func() time.Time { return now },
+			}
+		})
+
+		it("authorizes requests with a masked installation token scoped to the repository", func() {
+			a.On("CreateInstallationToken", mock.Anything, int64(1), &github.InstallationTokenOptions{Repositories: []string{"test-repository"}}).
+				Return(&github.InstallationToken{
+					Token:     github.Ptr("test-token-1"),
+					ExpiresAt: &github.Timestamp{Time: now.Add(time.Hour)},
+				}, nil, nil).
+				Once()
+			tk.On("AddMask", "test-token-1")
+
+			req, err := http.NewRequest(http.MethodGet, "https://api.github.com", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = transport.RoundTrip(req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(authorization).To(Equal("Bearer test-token-1"))
+
+			now = now.Add(30 * time.Minute)
+			_, err = transport.RoundTrip(req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(authorization).To(Equal("Bearer test-token-1"))
+
+			a.AssertExpectations(t)
+		})
+
+		it("scopes installation token to other repositories", func() {
+			transport.Repositories = []string{"test-namespaces"}
+			a.On("CreateInstallationToken", mock.Anything, int64(1), &github.InstallationTokenOptions{Repositories: []string{"test-repository", "test-namespaces"}}).
+				Return(&github.InstallationToken{
+					Token:     github.Ptr("test-token-1"),
+					ExpiresAt: &github.Timestamp{Time: now.Add(time.Hour)},
+				}, nil, nil).
+				Once()
+			tk.On("AddMask", "test-token-1")
+
+			req, err := http.NewRequest(http.MethodGet, "https://api.github.com", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = transport.RoundTrip(req)
+			Expect(err).NotTo(HaveOccurred())
+
+			a.AssertExpectations(t)
+		})
+
+		it("refreshes installation token before it expires", func() {
+			a.On("CreateInstallationToken", mock.Anything, int64(1), mock.Anything).
+				Return(&github.InstallationToken{
+					Token:     github.Ptr("test-token-1"),
+					ExpiresAt: &github.Timestamp{Time: now.Add(time.Hour)},
+				}, nil, nil).
+				Once()
+			a.On("CreateInstallationToken", mock.Anything, int64(1), mock.Anything).
+				Return(&github.InstallationToken{
+					Token:     github.Ptr("test-token-2"),
+					ExpiresAt: &github.Timestamp{Time: now.Add(2 * time.Hour)},
+				}, nil, nil).
+				Once()
+			tk.On("AddMask", mock.Anything)
+			tk.On("Debugf", mock.Anything, mock.Anything, mock.Anything)
+
+			req, err := http.NewRequest(http.MethodGet, "https://api.github.com", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = transport.RoundTrip(req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(authorization).To(Equal("Bearer test-token-1"))
+
+			now = now.Add(56 * time.Minute)
+			_, err = transport.RoundTrip(req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(authorization).To(Equal("Bearer test-token-2"))
+
+			tk.AssertCalled(t, "AddMask", "test-token-2")
+			a.AssertExpectations(t)
+		})
+	}, spec.Report(report.Terminal{}))
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package index
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+)
+
+var validDigest = regexp.MustCompile(`^sha256:[A-Fa-f0-9]{64}$`)
+
+// ParseAddress parses a buildpackage address that must be in digest form.  Docker Hub short forms are normalized to
+// their fully qualified index.docker.io/library form and any tag alongside the digest is dropped.
+func ParseAddress(address string) (name.Digest, error) {
+	if address == "" {
+		return name.Digest{}, fmt.Errorf("address must be set")
+	}
+
+	i := strings.LastIndex(address, "@")
+	if i == -1 {
+		return name.Digest{}, fmt.Errorf("address %s must be in digest form {host}/{repository}@sha256:{digest}", address)
+	}
+
+	base, digest := address[:i], address[i+1:]
+	if !validDigest.MatchString(digest) {
+		return name.Digest{}, fmt.Errorf("address %s has invalid digest %s, must be sha256: followed by 64 hexadecimal characters", address, digest)
+	}
+
+	if j := strings.LastIndex(base, ":"); j > strings.LastIndex(base, "/") {
+		base = base[:j]
+	}
+
+	if s := strings.SplitN(base, "/", 2); len(s) == 2 && (strings.ContainsAny(s[0], ".:") || s[0] == "localhost") {
+		if _, err := name.NewRegistry(s[0]); err != nil {
+			return name.Digest{}, fmt.Errorf("address %s has invalid registry host %s", address, s[0])
+		}
+	}
+
+	repository, err := name.NewRepository(base)
+	if err != nil {
+		return name.Digest{}, fmt.Errorf("address %s has invalid repository\n%w", address, err)
+	}
+
+	d, err := name.NewDigest(fmt.Sprintf("%s@%s", repository.Name(), strings.ToLower(digest)))
+	if err != nil {
+		return name.Digest{}, fmt.Errorf("unable to parse address %s\n%w", address, err)
+	}
+
+	return d, nil
+}
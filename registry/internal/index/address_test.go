@@ -0,0 +1,89 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package index_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+
+	"github.com/buildpacks/github-actions/registry/internal/index"
+)
+
+func TestAddress(t *testing.T) {
+	spec.Run(t, "address", func(t *testing.T, context spec.G, it spec.S) {
+		const digest = "sha256:133f2117e15569ca59645eddad78f4a6a675c435f9614e4b137364274f3a7614"
+
+		var (
+			Expect = NewWithT(t).Expect
+		)
+
+		it("parses fully qualified address", func() {
+			d, err := index.ParseAddress("host.com:443/repository/image@" + digest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(d.Name()).To(Equal("host.com:443/repository/image@" + digest))
+		})
+
+		it("parses single label host with port", func() {
+			d, err := index.ParseAddress("registry:5000/image@" + digest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(d.Name()).To(Equal("registry:5000/image@" + digest))
+		})
+
+		it("normalizes docker hub address", func() {
+			d, err := index.ParseAddress("image@" + digest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(d.Name()).To(Equal("index.docker.io/library/image@" + digest))
+
+			d, err = index.ParseAddress("docker.io/library/image@" + digest)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(d.Name()).To(Equal("index.docker.io/library/image@" + digest))
+		})
+
+		it("drops tag and lowercases digest", func() {
+			d, err := index.ParseAddress("host.com/image:tag@sha256:133F2117E15569CA59645EDDAD78F4A6A675C435F9614E4B137364274F3A7614")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(d.Name()).To(Equal("host.com/image@" + digest))
+		})
+
+		it("fails if address is empty", func() {
+			_, err := index.ParseAddress("")
+			Expect(err).To(MatchError("address must be set"))
+		})
+
+		it("fails if address is not in digest form", func() {
+			_, err := index.ParseAddress("host.com/image:tag")
+			Expect(err).To(MatchError("address host.com/image:tag must be in digest form {host}/{repository}@sha256:{digest}"))
+		})
+
+		it("fails if digest is invalid", func() {
+			_, err := index.ParseAddress("host.com/image@sha256:abc")
+			Expect(err).To(MatchError("address host.com/image@sha256:abc has invalid digest sha256:abc, must be sha256: followed by 64 hexadecimal characters"))
+		})
+
+		it("fails if registry host is invalid", func() {
+			_, err := index.ParseAddress("host.com:abc/image@" + digest)
+			Expect(err).To(MatchError("address host.com:abc/image@" + digest + " has invalid registry host host.com:abc"))
+		})
+
+		it("fails if repository is invalid", func() {
+			_, err := index.ParseAddress("host.com/Image@" + digest)
+			Expect(err).To(MatchError(HavePrefix("address host.com/Image@" + digest + " has invalid repository\nrepository can only contain")))
+		})
+	})
+}
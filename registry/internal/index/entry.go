@@ -24,11 +24,14 @@ import (
 )
 
 type Entry struct {
-	Namespace string `json:"ns"`
-	Name      string `json:"name"`
-	Version   string `json:"version"`
-	Yanked    bool   `json:"yanked"`
-	Address   string `json:"addr"`
+	Namespace   string `json:"ns"`
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Yanked      bool   `json:"yanked"`
+	Deprecated  bool   `json:"deprecated,omitempty"`
+	Reason      string `json:"reason,omitempty"`
+	Replacement string `json:"replacement-version,omitempty"`
+	Address     string `json:"addr"`
 }
 
 func MarshalEntries(entries []Entry) (string, error) {
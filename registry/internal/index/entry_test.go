@@ -71,6 +71,28 @@ func TestEntry(t *testing.T) {
 			)))
 		})
 
+		it("marshals yank details", func() {
+			Expect(index.MarshalEntries([]index.Entry{
+				{
+					Namespace: "test-namespace",
+					Name:      "test-name",
+					Version:   "test-version",
+					Address:   "test-address",
+				},
+				{
+					Namespace:   "test-namespace",
+					Name:        "test-name",
+					Version:     "test-version",
+					Yanked:      true,
+					Reason:      "test-reason",
+					Replacement: "test-replacement",
+					Address:     "test-address",
+				},
+			})).To(Equal(`{"ns":"test-namespace","name":"test-name","version":"test-version","yanked":false,"addr":"test-address"}
+{"ns":"test-namespace","name":"test-name","version":"test-version","yanked":true,"reason":"test-reason","replacement-version":"test-replacement","addr":"test-address"}
+`))
+		})
+
 		it("unmarshals entries", func() {
 			Expect(index.UnmarshalEntries(fmt.Sprintf("%s\n%s\n",
 				asJSONString(index.Entry{
@@ -0,0 +1,176 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package index
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+
+	"github.com/buildpacks/github-actions/internal/toolkit"
+	"github.com/buildpacks/github-actions/registry/internal/services"
+)
+
+// AnyNamespace is the key of the address rule applied to namespaces without a rule of their own.
+const AnyNamespace = "*"
+
+// AddressPolicy maps namespaces to the registry hosts and repositories their buildpackages may be published to.
+type AddressPolicy map[string]AddressRule
+
+// AddressRule lists allowed registry hosts and repository prefixes.  An empty list allows anything.
+type AddressRule struct {
+	Hosts    []string `json:"hosts,omitempty"`
+	Prefixes []string `json:"repository-prefixes,omitempty"`
+}
+
+// LoadAddressPolicy reads the policy from the address-policy input or, if address-policy-file is set, from that file
+// in the given repository.  If neither is set, the returned policy allows every address.
+func LoadAddressPolicy(tk toolkit.Toolkit, repositories services.RepositoriesService, owner string, repository string) (AddressPolicy, error) {
+	if s, ok := tk.GetInput("address-policy"); ok && s != "" {
+		p, err := ParseAddressPolicy(s)
+		if err != nil {
+			return nil, toolkit.FailedErrorf("invalid address-policy\n%w", err)
+		}
+
+		return p, nil
+	}
+
+	file, ok := AddressPolicyFile(tk)
+	if !ok {
+		return nil, nil
+	}
+
+	if repositories == nil {
+		return nil, toolkit.FailedError("token must be set to read address-policy-file")
+	}
+
+	content, _, resp, err := repositories.GetContents(context.Background(), owner, repository, file, nil)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, toolkit.FailedErrorf("address policy %s does not exist in %s/%s", file, owner, repository)
+	} else if err != nil {
+		return nil, toolkit.FailedErrorf("unable to read address policy %s\n%w", file, err)
+	}
+
+	s, err := content.GetContent()
+	if err != nil {
+		return nil, toolkit.FailedErrorf("unable to get address policy content\n%w", err)
+	}
+
+	p, err := ParseAddressPolicy(s)
+	if err != nil {
+		return nil, toolkit.FailedErrorf("invalid address policy %s\n%w", file, err)
+	}
+
+	return p, nil
+}
+
+// AddressPolicyFile returns the address-policy-file input if LoadAddressPolicy reads the policy from that file.
+func AddressPolicyFile(tk toolkit.Toolkit) (string, bool) {
+	if s, ok := tk.GetInput("address-policy"); ok && s != "" {
+		return "", false
+	}
+
+	if s, ok := tk.GetInput("address-policy-file"); ok && s != "" {
+		return s, true
+	}
+
+	return "", false
+}
+
+// ParseAddressPolicy parses a JSON address policy, normalizing hosts and repository prefixes the same way as
+// addresses.
+func ParseAddressPolicy(s string) (AddressPolicy, error) {
+	d := json.NewDecoder(strings.NewReader(s))
+	d.DisallowUnknownFields()
+
+	var p AddressPolicy
+	if err := d.Decode(&p); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal address policy\n%w", err)
+	}
+
+	for ns, r := range p {
+		for i, h := range r.Hosts {
+			reg, err := name.NewRegistry(h)
+			if err != nil {
+				return nil, fmt.Errorf("namespace %s has invalid registry host %s", ns, h)
+			}
+			r.Hosts[i] = reg.RegistryStr()
+		}
+
+		for i, prefix := range r.Prefixes {
+			repo, err := name.NewRepository(prefix)
+			if err != nil {
+				return nil, fmt.Errorf("namespace %s has invalid repository prefix %s", ns, prefix)
+			}
+			r.Prefixes[i] = repo.Name()
+		}
+	}
+
+	return p, nil
+}
+
+// Check returns an error naming the registry host or repository of address if it is not allowed for namespace.
+func (p AddressPolicy) Check(namespace string, address string) error {
+	r, ok := p[namespace]
+	if !ok {
+		r, ok = p[AnyNamespace]
+	}
+	if !ok {
+		return nil
+	}
+
+	d, err := ParseAddress(address)
+	if err != nil {
+		return err
+	}
+
+	if len(r.Hosts) > 0 && !contains(r.Hosts, d.RegistryStr()) {
+		return fmt.Errorf("registry host %s is not allowed for namespace %s, allowed hosts are %s",
+			d.RegistryStr(), namespace, strings.Join(r.Hosts, ", "))
+	}
+
+	if len(r.Prefixes) > 0 && !hasPrefix(r.Prefixes, d.Context().Name()) {
+		return fmt.Errorf("repository %s on registry host %s is not allowed for namespace %s, allowed repository prefixes are %s",
+			d.Context().Name(), d.RegistryStr(), namespace, strings.Join(r.Prefixes, ", "))
+	}
+
+	return nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasPrefix(prefixes []string, repository string) bool {
+	for _, p := range prefixes {
+		if repository == p || strings.HasPrefix(repository, p+"/") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,150 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package index_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/go-github/v89/github"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/buildpacks/github-actions/internal/toolkit"
+	"github.com/buildpacks/github-actions/registry/internal/index"
+	"github.com/buildpacks/github-actions/registry/internal/services"
+)
+
+func TestPolicy(t *testing.T) {
+	spec.Run(t, "policy", func(t *testing.T, context spec.G, it spec.S) {
+		const digest = "sha256:133f2117e15569ca59645eddad78f4a6a675c435f9614e4b137364274f3a7614"
+
+		var (
+			Expect = NewWithT(t).Expect
+
+			r     = &services.MockRepositoriesService{}
+			rOpts *github.RepositoryContentGetOptions
+			tk    = &toolkit.MockToolkit{}
+		)
+
+		context("check", func() {
+			var policy index.AddressPolicy
+
+			it.Before(func() {
+				var err error
+				policy, err = index.ParseAddressPolicy(`{
+  "*": { "hosts": ["gcr.io", "docker.io"] },
+  "test-namespace": { "hosts": ["ghcr.io"], "repository-prefixes": ["ghcr.io/test-org"] }
+}`)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			it("allows address on allowed host", func() {
+				Expect(policy.Check("other-namespace", "gcr.io/test/image@"+digest)).To(Succeed())
+				Expect(policy.Check("other-namespace", "image@"+digest)).To(Succeed())
+			})
+
+			it("rejects address on other host", func() {
+				Expect(policy.Check("other-namespace", "quay.io/test/image@"+digest)).
+					To(MatchError("registry host quay.io is not allowed for namespace other-namespace, allowed hosts are gcr.io, index.docker.io"))
+			})
+
+			it("applies namespace rule instead of default rule", func() {
+				Expect(policy.Check("test-namespace", "ghcr.io/test-org/image@"+digest)).To(Succeed())
+				Expect(policy.Check("test-namespace", "gcr.io/test-org/image@"+digest)).
+					To(MatchError("registry host gcr.io is not allowed for namespace test-namespace, allowed hosts are ghcr.io"))
+			})
+
+			it("rejects repository outside of prefixes", func() {
+				Expect(policy.Check("test-namespace", "ghcr.io/test-org-2/image@"+digest)).
+					To(MatchError("repository ghcr.io/test-org-2/image on registry host ghcr.io is not allowed for namespace test-namespace, allowed repository prefixes are ghcr.io/test-org"))
+			})
+
+			it("allows everything without rule", func() {
+				Expect(index.AddressPolicy{}.Check("test-namespace", "test-address")).To(Succeed())
+			})
+		})
+
+		it("fails on invalid policy", func() {
+			_, err := index.ParseAddressPolicy(`{"*": {"registries": ["gcr.io"]}}`)
+			Expect(err).To(MatchError(ContainSubstring("unable to unmarshal address policy")))
+
+			_, err = index.ParseAddressPolicy(`{"*": {"hosts": ["gcr.io/"]}}`)
+			Expect(err).To(MatchError("namespace * has invalid registry host gcr.io/"))
+		})
+
+		context("load", func() {
+			it("returns nil without inputs", func() {
+				tk.On("GetInput", "address-policy").Return("", false)
+				tk.On("GetInput", "address-policy-file").Return("", false)
+
+				Expect(index.LoadAddressPolicy(tk, r, "test-owner", "test-repository")).To(BeNil())
+			})
+
+			it("parses input", func() {
+				tk.On("GetInput", "address-policy").Return(`{"*": {"hosts": ["gcr.io"]}}`, true)
+
+				Expect(index.LoadAddressPolicy(tk, r, "test-owner", "test-repository")).
+					To(Equal(index.AddressPolicy{"*": {Hosts: []string{"gcr.io"}}}))
+			})
+
+			it("reads file", func() {
+				tk.On("GetInput", "address-policy").Return("", false)
+				tk.On("GetInput", "address-policy-file").Return("policy.json", true)
+				r.On("GetContents", mock.Anything, "test-owner", "test-repository", "policy.json", rOpts).
+					Return(&github.RepositoryContent{Content: github.Ptr(`{"*": {"hosts": ["gcr.io"]}}`)}, nil, nil, nil)
+
+				Expect(index.LoadAddressPolicy(tk, r, "test-owner", "test-repository")).
+					To(Equal(index.AddressPolicy{"*": {Hosts: []string{"gcr.io"}}}))
+			})
+
+			it("fails if file does not exist", func() {
+				tk.On("GetInput", "address-policy").Return("", false)
+				tk.On("GetInput", "address-policy-file").Return("policy.json", true)
+				r.On("GetContents", mock.Anything, "test-owner", "test-repository", "policy.json", rOpts).
+					Return(nil, nil, &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, nil)
+
+				_, err := index.LoadAddressPolicy(tk, r, "test-owner", "test-repository")
+				Expect(err).To(MatchError("::error ::address policy policy.json does not exist in test-owner/test-repository"))
+			})
+
+			it("reads file only without input", func() {
+				tk.On("GetInput", "address-policy").Return("", false)
+				tk.On("GetInput", "address-policy-file").Return("policy.json", true)
+
+				file, ok := index.AddressPolicyFile(tk)
+				Expect(ok).To(BeTrue())
+				Expect(file).To(Equal("policy.json"))
+
+				tk = &toolkit.MockToolkit{}
+				tk.On("GetInput", "address-policy").Return(`{"*": {"hosts": ["gcr.io"]}}`, true)
+
+				_, ok = index.AddressPolicyFile(tk)
+				Expect(ok).To(BeFalse())
+			})
+
+			it("fails if file is set without token", func() {
+				tk.On("GetInput", "address-policy").Return("", false)
+				tk.On("GetInput", "address-policy-file").Return("policy.json", true)
+
+				_, err := index.LoadAddressPolicy(tk, nil, "test-owner", "test-repository")
+				Expect(err).To(MatchError("::error ::token must be set to read address-policy-file"))
+			})
+		})
+	})
+}
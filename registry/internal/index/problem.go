@@ -0,0 +1,86 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package index
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/go-github/v89/github"
+
+	"github.com/buildpacks/github-actions/internal/toolkit"
+	"github.com/buildpacks/github-actions/registry/internal/services"
+)
+
+var issueRepository = regexp.MustCompile(`/repos/([^/]+)/([^/]+)$`)
+
+// Problem is an issue with a registry request that the requester can fix.
+type Problem struct {
+	Message string
+	Remedy  string
+}
+
+// Problems is an error describing every problem found with a registry request.
+type Problems []Problem
+
+func (p Problems) Error() string {
+	var s []string
+	for _, q := range p {
+		s = append(s, q.Message)
+	}
+
+	return toolkit.FailedError(strings.Join(s, "\n")).Error()
+}
+
+func (p Problems) Markdown() string {
+	var b strings.Builder
+
+	for _, q := range p {
+		_, _ = fmt.Fprintf(&b, "* **%s**\n", q.Message)
+		if q.Remedy != "" {
+			_, _ = fmt.Fprintf(&b, "  %s\n", q.Remedy)
+		}
+	}
+
+	return b.String()
+}
+
+// ReportProblems comments on a registry request issue with each problem and labels it as failed.
+func ReportProblems(issues services.IssuesService, owner string, repository string, number int, problems Problems) error {
+	if _, _, err := issues.CreateComment(context.Background(), owner, repository, number, &github.IssueComment{
+		Body: github.Ptr(fmt.Sprintf("This request could not be processed:\n\n%s", problems.Markdown())),
+	}); err != nil {
+		return fmt.Errorf("unable to comment on issue %d\n%w", number, err)
+	}
+
+	if _, _, err := issues.AddLabelsToIssue(context.Background(), owner, repository, number, []string{RequestFailureLabel}); err != nil {
+		return fmt.Errorf("unable to label issue %d\n%w", number, err)
+	}
+
+	return nil
+}
+
+// IssueRepository returns the owner and name of the repository a registry request issue was opened against.
+func IssueRepository(issue github.Issue) (string, string, error) {
+	if g := issueRepository.FindStringSubmatch(issue.GetRepositoryURL()); g != nil {
+		return g[1], g[2], nil
+	}
+
+	return "", "", fmt.Errorf("unable to determine repository of issue %d from repository URL %q", issue.GetNumber(), issue.GetRepositoryURL())
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package index_test
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v89/github"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+
+	"github.com/buildpacks/github-actions/registry/internal/index"
+)
+
+func TestProblem(t *testing.T) {
+	spec.Run(t, "problem", func(t *testing.T, context spec.G, it spec.S) {
+		var (
+			Expect = NewWithT(t).Expect
+
+			problems = index.Problems{
+				{Message: "test-message-1", Remedy: "test-remedy-1"},
+				{Message: "test-message-2"},
+			}
+		)
+
+		it("returns failed error", func() {
+			Expect(problems).To(MatchError("::error ::test-message-1%0Atest-message-2"))
+		})
+
+		it("renders markdown", func() {
+			Expect(problems.Markdown()).To(Equal("* **test-message-1**\n  test-remedy-1\n* **test-message-2**\n"))
+		})
+
+		it("returns issue repository", func() {
+			owner, repository, err := index.IssueRepository(github.Issue{
+				RepositoryURL: github.Ptr("https://api.github.com/repos/test-owner/test-repository"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(owner).To(Equal("test-owner"))
+			Expect(repository).To(Equal("test-repository"))
+		})
+
+		it("fails without issue repository", func() {
+			_, _, err := index.IssueRepository(github.Issue{Number: github.Ptr(1)})
+			Expect(err).To(MatchError(`unable to determine repository of issue 1 from repository URL ""`))
+		})
+	})
+}
@@ -17,18 +17,266 @@
 package index
 
 import (
+	"errors"
+	"fmt"
 	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
 )
 
 var (
 	ValidRequestId      = regexp.MustCompile(`^([a-zA-Z0-9.-]+)/([a-zA-Z0-9./-]+)$`)
 	ValidRequestVersion = regexp.MustCompile(`^(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?$`)
-	ValidRequestAddress = regexp.MustCompile(`(?:[a-z0-9](?:[a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z0-9][a-z0-9-]{0,61}[a-z0-9](?::[0-9]+)?/[^:]+@sha256:[A-Fa-f0-9]{64}`)
 )
 
 type Request struct {
-	ID      string
-	Version string
-	Address string `toml:"addr"`
-	Yank    bool   `toml:"yank,omitempty"`
+	ID          string
+	Version     string
+	Address     string `toml:"addr"`
+	Range       string `toml:"range,omitempty"`
+	Force       bool   `toml:"force,omitempty"`
+	Yank        bool   `toml:"yank,omitempty"`
+	Deprecate   bool   `toml:"deprecate,omitempty"`
+	Reason      string `toml:"reason,omitempty"`
+	Replacement string `toml:"replacement-version,omitempty"`
+	PreRelease  bool   `toml:"pre-release,omitempty"`
+}
+
+// BodyError is a problem with a registry request issue body at a line and column of the body.
+type BodyError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (b BodyError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", b.Line, b.Column, b.Message)
+}
+
+// BodyErrors is every problem found with a registry request issue body.
+type BodyErrors []BodyError
+
+func (b BodyErrors) Error() string {
+	var s []string
+	for _, e := range b {
+		s = append(s, e.Error())
+	}
+
+	return strings.Join(s, "\n")
+}
+
+// ParseRequest parses the single fenced TOML block of a registry request issue body or, if there is no code block, the
+// sections of a GitHub issue form.  Unknown and duplicated keys are rejected and every error is positioned relative to
+// the whole body.
+func ParseRequest(body string) (Request, error) {
+	lines := strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+
+	var (
+		start = -1
+		end   = -1
+	)
+	for i, l := range lines {
+		t := strings.TrimSpace(l)
+		if !strings.HasPrefix(t, "```") {
+			continue
+		}
+
+		switch {
+		case start == -1:
+			if info := strings.TrimSpace(strings.TrimPrefix(t, "```")); info != "" && info != "toml" {
+				return Request{}, BodyErrors{{Line: i + 1, Column: 1, Message: fmt.Sprintf("code block must be TOML, found %s", info)}}
+			}
+			start = i
+		case end == -1:
+			end = i
+		default:
+			return Request{}, BodyErrors{{Line: i + 1, Column: 1, Message: "body must contain exactly one code block"}}
+		}
+	}
+
+	if start == -1 {
+		for _, l := range lines {
+			if strings.HasPrefix(l, "### ") {
+				return parseForm(lines)
+			}
+		}
+
+		return Request{}, BodyErrors{{Line: 1, Column: 1, Message: "body must contain a ```toml code block or issue form sections"}}
+	}
+	if end == -1 {
+		return Request{}, BodyErrors{{Line: start + 1, Column: 1, Message: "code block is not closed"}}
+	}
+
+	block := lines[start+1 : end]
+	doc := strings.Join(block, "\n")
+
+	position := func(row int, column int) (int, int) {
+		return start + 1 + row, column
+	}
+
+	var raw map[string]interface{}
+	if err := toml.Unmarshal([]byte(doc), &raw); err != nil {
+		return Request{}, bodyErrors(err, position)
+	}
+
+	var errs BodyErrors
+	seen := make(map[string]string)
+	for i, l := range block {
+		k := strings.TrimSpace(strings.SplitN(l, "=", 2)[0])
+		if _, ok := raw[k]; !ok || !strings.Contains(l, "=") {
+			continue
+		}
+
+		if p, ok := seen[strings.ToLower(k)]; ok {
+			line, column := position(i+1, strings.Index(l, k)+1)
+			errs = append(errs, BodyError{Line: line, Column: column, Message: fmt.Sprintf("key %s duplicates %s", k, p)})
+			continue
+		}
+		seen[strings.ToLower(k)] = k
+	}
+	if len(errs) > 0 {
+		return Request{}, errs
+	}
+
+	var request Request
+	d := toml.NewDecoder(strings.NewReader(doc))
+	d.DisallowUnknownFields()
+	if err := d.Decode(&request); err != nil {
+		return Request{}, bodyErrors(err, position)
+	}
+
+	return request, nil
+}
+
+var formSections = map[string]string{
+	"id":                  "id",
+	"version":             "version",
+	"addr":                "addr",
+	"address":             "addr",
+	"range":               "range",
+	"version-range":       "range",
+	"force":               "force",
+	"yank":                "yank",
+	"deprecate":           "deprecate",
+	"reason":              "reason",
+	"replacement-version": "replacement-version",
+	"pre-release":         "pre-release",
+}
+
+func parseForm(lines []string) (Request, error) {
+	type section struct {
+		line      int
+		valueLine int
+		value     []string
+	}
+
+	var (
+		errs     BodyErrors
+		key      string
+		sections = make(map[string]*section)
+	)
+
+	for i, l := range lines {
+		if strings.HasPrefix(l, "### ") {
+			heading := strings.TrimSpace(strings.TrimPrefix(l, "### "))
+
+			k, ok := formSections[strings.ReplaceAll(strings.ToLower(heading), " ", "-")]
+			if !ok {
+				errs = append(errs, BodyError{Line: i + 1, Column: 5, Message: fmt.Sprintf("unknown section %s", heading)})
+				key = ""
+				continue
+			}
+
+			if _, ok := sections[k]; ok {
+				errs = append(errs, BodyError{Line: i + 1, Column: 5, Message: fmt.Sprintf("section %s is already defined", heading)})
+				key = ""
+				continue
+			}
+
+			key = k
+			sections[k] = &section{line: i + 1}
+			continue
+		}
+
+		if key != "" {
+			if sections[key].valueLine == 0 && strings.TrimSpace(l) != "" {
+				sections[key].valueLine = i + 1
+			}
+			sections[key].value = append(sections[key].value, l)
+		}
+	}
+
+	value := func(key string) string {
+		s, ok := sections[key]
+		if !ok {
+			return ""
+		}
+
+		v := strings.TrimSpace(strings.Join(s.value, "\n"))
+		if v == "_No response_" {
+			return ""
+		}
+
+		return v
+	}
+
+	flag := func(key string) bool {
+		v := strings.ToLower(value(key))
+		if v == "" {
+			return false
+		}
+
+		if strings.Contains(v, "- [x]") {
+			return true
+		} else if strings.Contains(v, "- [ ]") {
+			return false
+		}
+
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			errs = append(errs, BodyError{Line: sections[key].valueLine, Column: 1, Message: fmt.Sprintf("%s must be true or false", key)})
+		}
+		return b
+	}
+
+	request := Request{
+		ID:          value("id"),
+		Version:     value("version"),
+		Address:     value("addr"),
+		Range:       value("range"),
+		Force:       flag("force"),
+		Yank:        flag("yank"),
+		Deprecate:   flag("deprecate"),
+		Reason:      value("reason"),
+		Replacement: value("replacement-version"),
+		PreRelease:  flag("pre-release"),
+	}
+
+	if len(errs) > 0 {
+		return Request{}, errs
+	}
+
+	return request, nil
+}
+
+func bodyErrors(err error, position func(int, int) (int, int)) error {
+	var strict *toml.StrictMissingError
+	if errors.As(err, &strict) {
+		var errs BodyErrors
+		for _, e := range strict.Errors {
+			line, column := position(e.Position())
+			errs = append(errs, BodyError{Line: line, Column: column, Message: fmt.Sprintf("unknown key %s", strings.Join(e.Key(), "."))})
+		}
+		return errs
+	}
+
+	var decode *toml.DecodeError
+	if errors.As(err, &decode) {
+		line, column := position(decode.Position())
+		return BodyErrors{{Line: line, Column: column, Message: strings.TrimPrefix(decode.Error(), "toml: ")}}
+	}
+
+	return err
 }
@@ -0,0 +1,116 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package index_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+
+	"github.com/buildpacks/github-actions/registry/internal/index"
+)
+
+func TestRequest(t *testing.T) {
+	spec.Run(t, "request", func(t *testing.T, context spec.G, it spec.S) {
+		var (
+			Expect = NewWithT(t).Expect
+		)
+
+		it("parses fenced block", func() {
+			Expect(index.ParseRequest("Some prose\r\n\r\n```toml\r\nid = \"test-namespace/test-name\"\r\nversion = \"1.0.0\"\r\n```\r\n")).
+				To(Equal(index.Request{ID: "test-namespace/test-name", Version: "1.0.0"}))
+		})
+
+		it("fails without a code block", func() {
+			_, err := index.ParseRequest("id = \"test-namespace/test-name\"")
+			Expect(err).To(Equal(index.BodyErrors{{Line: 1, Column: 1, Message: "body must contain a ```toml code block or issue form sections"}}))
+		})
+
+		it("fails with more than one code block", func() {
+			_, err := index.ParseRequest("```\nid = \"test-namespace/test-name\"\n```\n\n```\nversion = \"1.0.0\"\n```")
+			Expect(err).To(Equal(index.BodyErrors{{Line: 5, Column: 1, Message: "body must contain exactly one code block"}}))
+		})
+
+		it("fails with a non-TOML code block", func() {
+			_, err := index.ParseRequest("```json\n{}\n```")
+			Expect(err).To(Equal(index.BodyErrors{{Line: 1, Column: 1, Message: "code block must be TOML, found json"}}))
+		})
+
+		it("fails with an unclosed code block", func() {
+			_, err := index.ParseRequest("text\n```\nid = \"test-namespace/test-name\"")
+			Expect(err).To(Equal(index.BodyErrors{{Line: 2, Column: 1, Message: "code block is not closed"}}))
+		})
+
+		it("fails with unknown keys", func() {
+			_, err := index.ParseRequest("text\n```\nid = \"test-namespace/test-name\"\nfoo = 1\nbar = 2\n```")
+			Expect(err).To(Equal(index.BodyErrors{
+				{Line: 4, Column: 1, Message: "unknown key foo"},
+				{Line: 5, Column: 1, Message: "unknown key bar"},
+			}))
+			Expect(err).To(MatchError("line 4, column 1: unknown key foo\nline 5, column 1: unknown key bar"))
+		})
+
+		it("fails with duplicate keys", func() {
+			_, err := index.ParseRequest("```\nid = \"test-namespace/test-name\"\nid = \"test-namespace/test-name\"\n```")
+			Expect(err).To(Equal(index.BodyErrors{{Line: 3, Column: 1, Message: "key id is already defined"}}))
+		})
+
+		it("fails with keys that differ only in case", func() {
+			_, err := index.ParseRequest("```\nid = \"test-namespace/test-name\"\n  ID = \"test-namespace/test-name\"\n```")
+			Expect(err).To(Equal(index.BodyErrors{{Line: 3, Column: 3, Message: "key ID duplicates id"}}))
+		})
+
+		it("fails with invalid TOML", func() {
+			_, err := index.ParseRequest("```\nid = \nversion = \"1.0.0\"\n```")
+			Expect(err).To(Equal(index.BodyErrors{{Line: 2, Column: 6, Message: "unexpected character U+000A at start of value"}}))
+		})
+
+		context("issue form", func() {
+
+			it("parses sections", func() {
+				Expect(index.ParseRequest("### ID\n\ntest-namespace/test-name\n\n### Version\n\n1.0.0\n\n" +
+					"### Address\n\nhost/repository@sha256:test-digest\n\n### Reason\n\n_No response_\n\n" +
+					"### Yank\n\n- [X] Yank this version\n")).
+					To(Equal(index.Request{ID: "test-namespace/test-name", Version: "1.0.0", Address: "host/repository@sha256:test-digest", Yank: true}))
+			})
+
+			it("parses unchecked and boolean sections", func() {
+				Expect(index.ParseRequest("### ID\r\n\r\ntest-namespace/test-name\r\n\r\n### Version Range\r\n\r\n>=1.0.0\r\n\r\n" +
+					"### Deprecate\r\n\r\ntrue\r\n\r\n### Force\r\n\r\n- [ ] Yank every version\r\n")).
+					To(Equal(index.Request{ID: "test-namespace/test-name", Range: ">=1.0.0", Deprecate: true}))
+			})
+
+			it("fails with unknown and duplicate sections", func() {
+				_, err := index.ParseRequest("### ID\n\ntest-namespace/test-name\n\n### Homepage\n\ntest-homepage\n\n### id\n\ntest-namespace/test-name")
+				Expect(err).To(Equal(index.BodyErrors{
+					{Line: 5, Column: 5, Message: "unknown section Homepage"},
+					{Line: 9, Column: 5, Message: "section id is already defined"},
+				}))
+			})
+
+			it("fails with invalid boolean", func() {
+				_, err := index.ParseRequest("### ID\n\ntest-namespace/test-name\n\n### Yank\n\nmaybe\n")
+				Expect(err).To(Equal(index.BodyErrors{{Line: 7, Column: 1, Message: "yank must be true or false"}}))
+
+				_, err = index.ParseRequest("### ID\ntest-namespace/test-name\n### Force\n\n\n  perhaps\n")
+				Expect(err).To(Equal(index.BodyErrors{{Line: 6, Column: 1, Message: "force must be true or false"}}))
+			})
+
+		})
+	})
+}
@@ -16,6 +16,19 @@
 
 package namespace
 
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/buildpacks/github-actions/registry/internal/services"
+)
+
+// ErrNotFound is returned by Read if the namespace does not exist.
+var ErrNotFound = errors.New("namespace does not exist")
+
 var restrictedNamespaces = []string{
 	"buildpack",
 	"buildpack-io",
@@ -50,3 +63,25 @@ func IsRestricted(namespace string) bool {
 
 	return false
 }
+
+// Read reads a namespace from the namespaces repository.
+func Read(repositories services.RepositoriesService, owner string, repository string, namespace string) (Namespace, error) {
+	content, _, resp, err := repositories.GetContents(context.Background(), owner, repository, Path(namespace), nil)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return Namespace{}, ErrNotFound
+	} else if err != nil {
+		return Namespace{}, fmt.Errorf("unable to read namespace %s\n%w", namespace, err)
+	}
+
+	s, err := content.GetContent()
+	if err != nil {
+		return Namespace{}, fmt.Errorf("unable to get namespace content\n%w", err)
+	}
+
+	var n Namespace
+	if err := json.Unmarshal([]byte(s), &n); err != nil {
+		return Namespace{}, fmt.Errorf("unable to unmarshal owners\n%w", err)
+	}
+
+	return n, nil
+}
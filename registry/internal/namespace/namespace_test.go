@@ -17,23 +17,47 @@
 package namespace_test
 
 import (
+	"net/http"
+	"path/filepath"
 	"testing"
 
+	"github.com/google/go-github/v89/github"
 	. "github.com/onsi/gomega"
 	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/mock"
 
 	"github.com/buildpacks/github-actions/registry/internal/namespace"
+	"github.com/buildpacks/github-actions/registry/internal/services"
 )
 
 func TestNamespace(t *testing.T) {
 	spec.Run(t, "namespace", func(t *testing.T, context spec.G, it spec.S) {
 		var (
 			Expect = NewWithT(t).Expect
+
+			r     = &services.MockRepositoriesService{}
+			rOpts *github.RepositoryContentGetOptions
 		)
 
 		it("identifies restricted namespaces", func() {
 			Expect(namespace.IsRestricted("cnb")).To(BeTrue())
 			Expect(namespace.IsRestricted("test-namespace")).To(BeFalse())
 		})
+
+		it("reads namespace", func() {
+			r.On("GetContents", mock.Anything, "test-owner", "test-repository", filepath.Join("v1", "test-namespace.json"), rOpts).
+				Return(&github.RepositoryContent{Content: github.Ptr(`{"owners":[{"id":1,"type":"github_user"}]}`)}, nil, nil, nil)
+
+			Expect(namespace.Read(r, "test-owner", "test-repository", "test-namespace")).
+				To(Equal(namespace.Namespace{Owners: []namespace.Owner{{ID: 1, Type: namespace.UserType}}}))
+		})
+
+		it("returns not found if namespace does not exist", func() {
+			r.On("GetContents", mock.Anything, "test-owner", "test-repository", filepath.Join("v1", "test-namespace.json"), rOpts).
+				Return(nil, nil, &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, nil)
+
+			_, err := namespace.Read(r, "test-owner", "test-repository", "test-namespace")
+			Expect(err).To(MatchError(namespace.ErrNotFound))
+		})
 	})
 }
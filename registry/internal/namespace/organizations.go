@@ -0,0 +1,49 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package namespace
+
+import (
+	"context"
+
+	"github.com/google/go-github/v89/github"
+
+	"github.com/buildpacks/github-actions/registry/internal/services"
+)
+
+func ListOrganizations(user string, organizations services.OrganizationsService) ([]int64, error) {
+	var ids []int64
+
+	opt := &github.ListOptions{PerPage: 100}
+
+	for {
+		orgs, rsp, err := organizations.List(context.Background(), user, opt)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, o := range orgs {
+			ids = append(ids, *o.ID)
+		}
+
+		if rsp.NextPage == 0 {
+			break
+		}
+		opt.Page = rsp.NextPage
+	}
+
+	return ids, nil
+}
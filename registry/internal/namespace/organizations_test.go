@@ -0,0 +1,49 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package namespace_test
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v89/github"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/buildpacks/github-actions/registry/internal/namespace"
+	"github.com/buildpacks/github-actions/registry/internal/services"
+)
+
+func TestOrganizations(t *testing.T) {
+	spec.Run(t, "organizations", func(t *testing.T, context spec.G, it spec.S) {
+		var (
+			Expect = NewWithT(t).Expect
+
+			o = &services.MockOrganizationsService{}
+		)
+
+		it("lists every page of organizations", func() {
+			o.On("List", mock.Anything, "test-user", &github.ListOptions{PerPage: 100}).
+				Return([]*github.Organization{{ID: github.Ptr(int64(1))}}, &github.Response{NextPage: 2}, nil).
+				Once()
+			o.On("List", mock.Anything, "test-user", &github.ListOptions{PerPage: 100, Page: 2}).
+				Return([]*github.Organization{{ID: github.Ptr(int64(2))}}, &github.Response{}, nil)
+
+			Expect(namespace.ListOrganizations("test-user", o)).To(Equal([]int64{1, 2}))
+		})
+	})
+}
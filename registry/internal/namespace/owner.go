@@ -16,6 +16,14 @@
 
 package namespace
 
+import (
+	"fmt"
+
+	"github.com/google/go-github/v89/github"
+
+	"github.com/buildpacks/github-actions/registry/internal/services"
+)
+
 const (
 	OrganizationType = "github_org"
 	UserType         = "github_user"
@@ -54,3 +62,17 @@ func ByOrganizations(ids []int64) OwnerPredicate {
 		return false
 	}
 }
+
+// IsUserOwner returns whether a user, or any organization the user publicly belongs to, is one of the owners.
+func IsUserOwner(owners []Owner, user github.User, organizations services.OrganizationsService) (bool, error) {
+	if IsOwner(owners, ByUser(user.GetID())) {
+		return true, nil
+	}
+
+	ids, err := ListOrganizations(user.GetLogin(), organizations)
+	if err != nil {
+		return false, fmt.Errorf("unable to list organizations for %s\n%w", user.GetLogin(), err)
+	}
+
+	return IsOwner(owners, ByOrganizations(ids)), nil
+}
@@ -17,18 +17,25 @@
 package namespace_test
 
 import (
+	"fmt"
 	"testing"
 
+	"github.com/google/go-github/v89/github"
 	. "github.com/onsi/gomega"
 	"github.com/sclevine/spec"
+	"github.com/stretchr/testify/mock"
 
 	"github.com/buildpacks/github-actions/registry/internal/namespace"
+	"github.com/buildpacks/github-actions/registry/internal/services"
 )
 
 func TestOwner(t *testing.T) {
 	spec.Run(t, "owner", func(t *testing.T, context spec.G, it spec.S) {
 		var (
 			Expect = NewWithT(t).Expect
+
+			o    = &services.MockOrganizationsService{}
+			user = github.User{ID: github.Ptr(int64(1)), Login: github.Ptr("test-user")}
 		)
 
 		it("identifies owner by predicate", func() {
@@ -54,5 +61,26 @@ func TestOwner(t *testing.T) {
 			Expect(namespace.ByOrganizations([]int64{1})(namespace.Owner{ID: 1, Type: namespace.UserType})).To(BeFalse())
 			Expect(namespace.ByOrganizations([]int64{1})(namespace.Owner{ID: 1, Type: namespace.OrganizationType})).To(BeTrue())
 		})
+
+		it("identifies user as owner", func() {
+			Expect(namespace.IsUserOwner([]namespace.Owner{{ID: 1, Type: namespace.UserType}}, user, o)).To(BeTrue())
+			o.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything)
+		})
+
+		it("identifies user as owner by organization", func() {
+			o.On("List", mock.Anything, "test-user", mock.Anything).
+				Return([]*github.Organization{{ID: github.Ptr(int64(2))}}, &github.Response{}, nil)
+
+			Expect(namespace.IsUserOwner([]namespace.Owner{{ID: 2, Type: namespace.OrganizationType}}, user, o)).To(BeTrue())
+			Expect(namespace.IsUserOwner([]namespace.Owner{{ID: 3, Type: namespace.OrganizationType}}, user, o)).To(BeFalse())
+		})
+
+		it("fails if organizations cannot be listed", func() {
+			o.On("List", mock.Anything, "test-user", mock.Anything).
+				Return(nil, nil, fmt.Errorf("test-error"))
+
+			_, err := namespace.IsUserOwner([]namespace.Owner{{ID: 2, Type: namespace.OrganizationType}}, user, o)
+			Expect(err).To(MatchError("unable to list organizations for test-user\ntest-error"))
+		})
 	})
 }
@@ -14,6 +14,45 @@ type MockIssuesService struct {
 	mock.Mock
 }
 
+// AddLabelsToIssue provides a mock function with given fields: ctx, owner, repo, number, labels
+func (_m *MockIssuesService) AddLabelsToIssue(ctx context.Context, owner string, repo string, number int, labels []string) ([]*github.Label, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, number, labels)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddLabelsToIssue")
+	}
+
+	var r0 []*github.Label
+	var r1 *github.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, []string) ([]*github.Label, *github.Response, error)); ok {
+		return rf(ctx, owner, repo, number, labels)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, []string) []*github.Label); ok {
+		r0 = rf(ctx, owner, repo, number, labels)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*github.Label)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int, []string) *github.Response); ok {
+		r1 = rf(ctx, owner, repo, number, labels)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, int, []string) error); ok {
+		r2 = rf(ctx, owner, repo, number, labels)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Create provides a mock function with given fields: ctx, owner, repo, issue
 func (_m *MockIssuesService) Create(ctx context.Context, owner string, repo string, issue *github.IssueRequest) (*github.Issue, *github.Response, error) {
 	ret := _m.Called(ctx, owner, repo, issue)
@@ -53,6 +92,84 @@ func (_m *MockIssuesService) Create(ctx context.Context, owner string, repo stri
 	return r0, r1, r2
 }
 
+// CreateComment provides a mock function with given fields: ctx, owner, repo, number, comment
+func (_m *MockIssuesService) CreateComment(ctx context.Context, owner string, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, number, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateComment")
+	}
+
+	var r0 *github.IssueComment
+	var r1 *github.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, *github.IssueComment) (*github.IssueComment, *github.Response, error)); ok {
+		return rf(ctx, owner, repo, number, comment)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, *github.IssueComment) *github.IssueComment); ok {
+		r0 = rf(ctx, owner, repo, number, comment)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.IssueComment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int, *github.IssueComment) *github.Response); ok {
+		r1 = rf(ctx, owner, repo, number, comment)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, int, *github.IssueComment) error); ok {
+		r2 = rf(ctx, owner, repo, number, comment)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Edit provides a mock function with given fields: ctx, owner, repo, number, issue
+func (_m *MockIssuesService) Edit(ctx context.Context, owner string, repo string, number int, issue *github.IssueRequest) (*github.Issue, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo, number, issue)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Edit")
+	}
+
+	var r0 *github.Issue
+	var r1 *github.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, *github.IssueRequest) (*github.Issue, *github.Response, error)); ok {
+		return rf(ctx, owner, repo, number, issue)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int, *github.IssueRequest) *github.Issue); ok {
+		r0 = rf(ctx, owner, repo, number, issue)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Issue)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int, *github.IssueRequest) *github.Response); ok {
+		r1 = rf(ctx, owner, repo, number, issue)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string, int, *github.IssueRequest) error); ok {
+		r2 = rf(ctx, owner, repo, number, issue)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // Get provides a mock function with given fields: ctx, owner, repo, number
 func (_m *MockIssuesService) Get(ctx context.Context, owner string, repo string, number int) (*github.Issue, *github.Response, error) {
 	ret := _m.Called(ctx, owner, repo, number)
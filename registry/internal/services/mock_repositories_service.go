@@ -53,6 +53,45 @@ func (_m *MockRepositoriesService) CreateFile(ctx context.Context, owner string,
 	return r0, r1, r2
 }
 
+// Get provides a mock function with given fields: ctx, owner, repo
+func (_m *MockRepositoriesService) Get(ctx context.Context, owner string, repo string) (*github.Repository, *github.Response, error) {
+	ret := _m.Called(ctx, owner, repo)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 *github.Repository
+	var r1 *github.Response
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*github.Repository, *github.Response, error)); ok {
+		return rf(ctx, owner, repo)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *github.Repository); ok {
+		r0 = rf(ctx, owner, repo)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*github.Repository)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) *github.Response); ok {
+		r1 = rf(ctx, owner, repo)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*github.Response)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, string) error); ok {
+		r2 = rf(ctx, owner, repo)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // GetContents provides a mock function with given fields: ctx, owner, repo, path, opts
 func (_m *MockRepositoriesService) GetContents(ctx context.Context, owner string, repo string, path string, opts *github.RepositoryContentGetOptions) (*github.RepositoryContent, []*github.RepositoryContent, *github.Response, error) {
 	ret := _m.Called(ctx, owner, repo, path, opts)
@@ -24,8 +24,25 @@ import (
 
 //go:generate mockery --all  --inpackage --case=underscore
 
+type AppsService interface {
+	CreateInstallationToken(ctx context.Context, id int64, opts *github.InstallationTokenOptions) (*github.InstallationToken, *github.Response, error)
+	GetRepositoryInstallation(ctx context.Context, owner string, repo string) (*github.Installation, *github.Response, error)
+}
+
+type GitService interface {
+	CreateCommit(ctx context.Context, owner string, repo string, commit github.Commit, opts *github.CreateCommitOptions) (*github.Commit, *github.Response, error)
+	CreateRef(ctx context.Context, owner string, repo string, ref github.CreateRef) (*github.Reference, *github.Response, error)
+	CreateTree(ctx context.Context, owner string, repo string, baseTree string, entries []*github.TreeEntry) (*github.Tree, *github.Response, error)
+	GetCommit(ctx context.Context, owner string, repo string, sha string) (*github.Commit, *github.Response, error)
+	GetRef(ctx context.Context, owner string, repo string, ref string) (*github.Reference, *github.Response, error)
+	UpdateRef(ctx context.Context, owner string, repo string, ref string, body github.UpdateRef) (*github.Reference, *github.Response, error)
+}
+
 type IssuesService interface {
+	AddLabelsToIssue(ctx context.Context, owner string, repo string, number int, labels []string) ([]*github.Label, *github.Response, error)
 	Create(ctx context.Context, owner string, repo string, issue *github.IssueRequest) (*github.Issue, *github.Response, error)
+	CreateComment(ctx context.Context, owner string, repo string, number int, comment *github.IssueComment) (*github.IssueComment, *github.Response, error)
+	Edit(ctx context.Context, owner string, repo string, number int, issue *github.IssueRequest) (*github.Issue, *github.Response, error)
 	Get(ctx context.Context, owner string, repo string, number int) (*github.Issue, *github.Response, error)
 }
 
@@ -33,7 +50,15 @@ type OrganizationsService interface {
 	List(ctx context.Context, user string, opts *github.ListOptions) ([]*github.Organization, *github.Response, error)
 }
 
+type PullRequestsService interface {
+	Create(ctx context.Context, owner string, repo string, pull *github.NewPullRequest) (*github.PullRequest, *github.Response, error)
+	Edit(ctx context.Context, owner string, repo string, number int, pull *github.PullRequest) (*github.PullRequest, *github.Response, error)
+	Get(ctx context.Context, owner string, repo string, number int) (*github.PullRequest, *github.Response, error)
+	List(ctx context.Context, owner string, repo string, opts *github.PullRequestListOptions) ([]*github.PullRequest, *github.Response, error)
+}
+
 type RepositoriesService interface {
 	CreateFile(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentFileOptions) (*github.RepositoryContentResponse, *github.Response, error)
+	Get(ctx context.Context, owner, repo string) (*github.Repository, *github.Response, error)
 	GetContents(ctx context.Context, owner, repo, path string, opts *github.RepositoryContentGetOptions) (fileContent *github.RepositoryContent, directoryContent []*github.RepositoryContent, resp *github.Response, err error)
 }
@@ -0,0 +1,266 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package process
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/v89/github"
+	"gopkg.in/retry.v1"
+
+	verify "github.com/buildpacks/github-actions/buildpackage/verify-metadata"
+	"github.com/buildpacks/github-actions/internal/toolkit"
+	add "github.com/buildpacks/github-actions/registry/add-entry"
+	metadata "github.com/buildpacks/github-actions/registry/compute-metadata"
+	"github.com/buildpacks/github-actions/registry/internal/index"
+	"github.com/buildpacks/github-actions/registry/internal/services"
+	owner "github.com/buildpacks/github-actions/registry/verify-namespace-owner"
+	yank "github.com/buildpacks/github-actions/registry/yank-entry"
+)
+
+const (
+	StepFailed    = "failed"
+	StepSkipped   = "skipped"
+	StepSucceeded = "succeeded"
+)
+
+var remedies = map[string]string{
+	"Verify Namespace Owner": "Open the request from a GitHub account that owns the namespace, or that is a member of an organization that owns it.",
+	"Verify Buildpackage":    "`addr` must point to a buildpackage whose `io.buildpacks.buildpackage.metadata` label contains the requested `id` and `version`.",
+}
+
+type Step struct {
+	Name     string
+	Status   string
+	Problems index.Problems
+}
+
+func ProcessRequest(tk toolkit.Toolkit, git services.GitService, issues services.IssuesService, organizations services.OrganizationsService,
+	pulls services.PullRequestsService, repositories services.RepositoriesService, imageFn verify.ImageFunction, strategy retry.Strategy) error {
+
+	c, err := parseConfig(tk)
+	if err != nil {
+		return err
+	}
+
+	var issue github.Issue
+	if err := json.Unmarshal([]byte(c.Issue), &issue); err != nil {
+		return toolkit.FailedErrorf("unable to unmarshal issue\n%w", err)
+	}
+
+	if issue.Number == nil || issue.User == nil {
+		return toolkit.FailedError("issue must have a number and a user")
+	}
+
+	user, err := json.Marshal(issue.User)
+	if err != nil {
+		return toolkit.FailedErrorf("unable to marshal user\n%w", err)
+	}
+
+	var steps []Step
+	run := func(name string, inputs map[string]string, fn func(toolkit.Toolkit) error) (map[string]string, bool) {
+		tk.StartGroup(name)
+		defer tk.EndGroup()
+
+		s := &toolkit.ScopedToolkit{Toolkit: tk, Inputs: inputs}
+		if err := fn(s); err != nil {
+			tk.Errorf("%s failed: %s", name, toolkit.ErrorMessage(err))
+
+			var problems index.Problems
+			if !errors.As(err, &problems) {
+				problems = index.Problems{{Message: toolkit.ErrorMessage(err), Remedy: remedies[name]}}
+			}
+
+			steps = append(steps, Step{Name: name, Status: StepFailed, Problems: problems})
+			return nil, false
+		}
+
+		for k, v := range s.Outputs {
+			tk.SetOutput(k, v)
+		}
+
+		steps = append(steps, Step{Name: name, Status: StepSucceeded})
+		return s.Outputs, true
+	}
+
+	m, ok := run("Compute Metadata", map[string]string{"issue": c.Issue, "verify-owner": "false"}, func(tk toolkit.Toolkit) error {
+		return metadata.ComputeMetadata(tk, nil, nil, repositories)
+	})
+
+	// compute-metadata only sets an address for add requests
+	address, isAdd := m["address"]
+
+	if ok {
+		_, ok = run("Verify Namespace Owner", map[string]string{
+			"user":        string(user),
+			"owner":       c.NamespacesOwner,
+			"repository":  c.NamespacesRepository,
+			"namespace":   m["namespace"],
+			"request-url": issue.GetHTMLURL(),
+		}, func(tk toolkit.Toolkit) error {
+			return owner.VerifyNamespaceOwner(tk, git, nil, organizations, repositories, strategy)
+		})
+	} else {
+		steps = append(steps, Step{Name: "Verify Namespace Owner", Status: StepSkipped})
+	}
+
+	if ok && isAdd {
+		_, ok = run("Verify Buildpackage", map[string]string{
+			"id":      m["id"],
+			"version": m["version"],
+			"address": address,
+		}, func(tk toolkit.Toolkit) error {
+			return verify.VerifyMetadata(tk, imageFn)
+		})
+	} else {
+		steps = append(steps, Step{Name: "Verify Buildpackage", Status: StepSkipped})
+	}
+
+	entry := map[string]string{
+		"owner":       c.Owner,
+		"repository":  c.Repository,
+		"namespace":   m["namespace"],
+		"name":        m["name"],
+		"version":     m["version"],
+		"request-url": issue.GetHTMLURL(),
+	}
+
+	if ok && isAdd {
+		entry["address"] = address
+		entry["pre-release"] = m["pre-release"]
+		_, ok = run("Add Entry", entry, func(tk toolkit.Toolkit) error {
+			return add.AddEntry(tk, git, pulls, repositories, strategy)
+		})
+	} else if ok {
+		entry["deprecate"] = m["deprecate"]
+		entry["range"] = m["range"]
+		entry["force"] = m["force"]
+		entry["reason"] = m["reason"]
+		entry["replacement-version"] = m["replacement-version"]
+
+		step := "Yank Entry"
+		if m["deprecate"] == "true" {
+			step = "Deprecate Entry"
+		}
+
+		_, ok = run(step, entry, func(tk toolkit.Toolkit) error {
+			return yank.YankEntry(tk, git, pulls, repositories, strategy)
+		})
+	} else {
+		steps = append(steps, Step{Name: "Update Index", Status: StepSkipped})
+	}
+
+	if err := report(c, *issue.Number, steps, ok, issues); err != nil {
+		return err
+	}
+
+	if !ok {
+		return toolkit.FailedErrorf("Registry request %s failed", issue.GetHTMLURL())
+	}
+
+	fmt.Printf("Registry request %s succeeded\n", issue.GetHTMLURL())
+	return nil
+}
+
+func report(c config, number int, steps []Step, ok bool, issues services.IssuesService) error {
+	label := index.RequestSuccessLabel
+	if !ok {
+		label = index.RequestFailureLabel
+	}
+
+	if _, _, err := issues.CreateComment(context.Background(), c.Owner, c.Repository, number, &github.IssueComment{
+		Body: github.Ptr(FormatSteps(steps)),
+	}); err != nil {
+		return toolkit.FailedErrorf("unable to comment on issue %d\n%w", number, err)
+	}
+
+	if _, _, err := issues.AddLabelsToIssue(context.Background(), c.Owner, c.Repository, number, []string{label}); err != nil {
+		return toolkit.FailedErrorf("unable to label issue %d\n%w", number, err)
+	}
+
+	if _, _, err := issues.Edit(context.Background(), c.Owner, c.Repository, number, &github.IssueRequest{
+		State: github.Ptr("closed"),
+	}); err != nil {
+		return toolkit.FailedErrorf("unable to close issue %d\n%w", number, err)
+	}
+
+	return nil
+}
+
+func FormatSteps(steps []Step) string {
+	var b strings.Builder
+
+	b.WriteString("| Step | Result |\n")
+	b.WriteString("| ---- | ------ |\n")
+	for _, s := range steps {
+		_, _ = fmt.Fprintf(&b, "| %s | %s |\n", s.Name, s.Status)
+	}
+
+	for _, s := range steps {
+		if len(s.Problems) > 0 {
+			_, _ = fmt.Fprintf(&b, "\n**%s**\n\n%s", s.Name, s.Problems.Markdown())
+		}
+	}
+
+	return b.String()
+}
+
+type config struct {
+	Issue                string
+	Owner                string
+	Repository           string
+	NamespacesOwner      string
+	NamespacesRepository string
+}
+
+func parseConfig(tk toolkit.Toolkit) (config, error) {
+	var (
+		c  config
+		ok bool
+	)
+
+	c.Issue, ok = tk.GetInput("issue")
+	if !ok {
+		return config{}, toolkit.FailedError("issue must be set")
+	}
+
+	c.Owner, ok = tk.GetInput("owner")
+	if !ok {
+		return config{}, toolkit.FailedError("owner must be set")
+	}
+
+	c.Repository, ok = tk.GetInput("repository")
+	if !ok {
+		return config{}, toolkit.FailedError("repository must be set")
+	}
+
+	c.NamespacesOwner, ok = tk.GetInput("namespaces-owner")
+	if !ok {
+		c.NamespacesOwner = c.Owner
+	}
+
+	c.NamespacesRepository, ok = tk.GetInput("namespaces-repository")
+	if !ok {
+		c.NamespacesRepository = c.Repository
+	}
+
+	return c, nil
+}
@@ -0,0 +1,192 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package process_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/fake"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-github/v89/github"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/stretchr/testify/mock"
+	"gopkg.in/retry.v1"
+
+	verify "github.com/buildpacks/github-actions/buildpackage/verify-metadata"
+	"github.com/buildpacks/github-actions/internal/toolkit"
+	"github.com/buildpacks/github-actions/registry/internal/index"
+	"github.com/buildpacks/github-actions/registry/internal/namespace"
+	"github.com/buildpacks/github-actions/registry/internal/services"
+	process "github.com/buildpacks/github-actions/registry/process-request"
+)
+
+func TestProcessRequest(t *testing.T) {
+	spec.Run(t, "process-request", func(t *testing.T, context spec.G, it spec.S) {
+		const address = "host.com:443/repository/image@sha256:133f2117e15569ca59645eddad78f4a6a675c435f9614e4b137364274f3a7614"
+
+		var (
+			Expect           = NewWithT(t).Expect
+			ExpectWithOffset = NewWithT(t).ExpectWithOffset
+
+			g     = &services.MockGitService{}
+			i     = &services.MockIssuesService{}
+			o     = &services.MockOrganizationsService{}
+			p     = &services.MockPullRequestsService{}
+			r     = &services.MockRepositoriesService{}
+			rOpts *github.RepositoryContentGetOptions
+			s     = retry.LimitCount(2, retry.Regular{Min: 2})
+			tk    = &toolkit.MockToolkit{}
+
+			image   = &fake.FakeImage{}
+			imageFn = func(name.Reference, ...remote.Option) (v1.Image, error) { return image, nil }
+		)
+
+		asJSONString := func(v interface{}) string {
+			b, err := json.Marshal(v)
+			ExpectWithOffset(1, err).NotTo(HaveOccurred())
+
+			return string(b)
+		}
+
+		issue := func(body string) string {
+			return asJSONString(github.Issue{
+				Number:        github.Ptr(1),
+				HTMLURL:       github.Ptr("test-html-url"),
+				RepositoryURL: github.Ptr("https://api.github.com/repos/test-owner/test-repository"),
+				User:          &github.User{ID: github.Ptr(int64(1)), Login: github.Ptr("test-user")},
+				Body:          github.Ptr(body),
+			})
+		}
+
+		it.Before(func() {
+			tk.On("GetInput", "owner").Return("test-owner", true)
+			tk.On("GetInput", "repository").Return("test-repository", true)
+			tk.On("GetInput", "namespaces-owner").Return("", false)
+			tk.On("GetInput", "namespaces-repository").Return("", false)
+			tk.On("GetInput", "add-if-missing").Return("", false)
+			tk.On("GetInputList", "blocked_namespaces").Return(nil, false)
+			tk.On("GetInput", "author-name").Return("", false)
+			tk.On("GetInput", "author-email").Return("", false)
+			tk.On("GetInput", "committer-name").Return("", false)
+			tk.On("GetInput", "committer-email").Return("", false)
+			tk.On("GetInput", "commit-message").Return("", false)
+			tk.On("GetInput", "signing-key").Return("", false)
+			tk.On("GetInput", "pull-request").Return("", false)
+			tk.On("GetInput", "wait-for-merge").Return("", false)
+			tk.On("GetInput", "merge-timeout").Return("", false)
+			tk.On("GetInput", "require-monotonic-version").Return("", false)
+			tk.On("GetInput", "require-pre-release-flag").Return("", false)
+			tk.On("GetInput", "forbid-yanked-version-reuse").Return("", false)
+			tk.On("GetInput", "address-policy").Return("", false)
+			tk.On("GetInput", "address-policy-file").Return("", false)
+			tk.On("StartGroup", mock.Anything)
+			tk.On("EndGroup")
+			tk.On("SetOutput", mock.Anything, mock.Anything)
+
+			r.On("GetContents", mock.Anything, "test-owner", "test-repository", filepath.Join("v1", "test-namespace.json"), rOpts).
+				Return(&github.RepositoryContent{
+					Content: github.Ptr(asJSONString(namespace.Namespace{Owners: []namespace.Owner{{ID: 1, Type: namespace.UserType}}})),
+				}, nil, nil, nil)
+
+			i.On("Edit", mock.Anything, "test-owner", "test-repository", 1, &github.IssueRequest{State: github.Ptr("closed")}).
+				Return(&github.Issue{}, nil, nil)
+		})
+
+		context("add request", func() {
+			it.Before(func() {
+				tk.On("GetInput", "issue").
+					Return(issue(fmt.Sprintf("```\nid = \"test-namespace/test-name\"\nversion = \"0.0.0\"\naddr = \"%s\"\n```", address)), true)
+
+				image.ConfigFileReturns(&v1.ConfigFile{
+					Config: v1.Config{
+						Labels: map[string]string{verify.MetadataLabel: `{ "id": "test-namespace/test-name", "version": "0.0.0" }`},
+					},
+				}, nil)
+
+				r.On("GetContents", mock.Anything, "test-owner", "test-repository", filepath.Join("te", "st", "test-namespace_test-name"), rOpts).
+					Return(nil, nil, &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, nil)
+				r.On("CreateFile", mock.Anything, "test-owner", "test-repository", filepath.Join("te", "st", "test-namespace_test-name"), &github.RepositoryContentFileOptions{
+					Author: &github.CommitAuthor{
+						Name:  github.Ptr("buildpacks-bot"),
+						Email: github.Ptr("cncf-buildpacks-maintainers@lists.cncf.io"),
+					},
+					Message: github.Ptr("ADD test-namespace/test-name@0.0.0"),
+					Content: []byte(fmt.Sprintf("%s\n", asJSONString(index.Entry{
+						Namespace: "test-namespace",
+						Name:      "test-name",
+						Version:   "0.0.0",
+						Address:   address,
+					}))),
+				}).
+					Return(nil, nil, nil)
+			})
+
+			it("adds entry and closes issue", func() {
+				i.On("CreateComment", mock.Anything, "test-owner", "test-repository", 1, &github.IssueComment{
+					Body: github.Ptr(process.FormatSteps([]process.Step{
+						{Name: "Compute Metadata", Status: process.StepSucceeded},
+						{Name: "Verify Namespace Owner", Status: process.StepSucceeded},
+						{Name: "Verify Buildpackage", Status: process.StepSucceeded},
+						{Name: "Add Entry", Status: process.StepSucceeded},
+					})),
+				}).Return(&github.IssueComment{}, nil, nil)
+				i.On("AddLabelsToIssue", mock.Anything, "test-owner", "test-repository", 1, []string{index.RequestSuccessLabel}).
+					Return(nil, nil, nil)
+
+				Expect(process.ProcessRequest(tk, g, i, o, p, r, imageFn, s)).To(Succeed())
+			})
+		})
+
+		context("invalid request", func() {
+			it.Before(func() {
+				tk.On("GetInput", "issue").
+					Return(issue("```\nid = \"test-namespace\"\nversion = \"0.0.0\"\n```"), true)
+				tk.On("Errorf", mock.Anything, mock.Anything, mock.Anything)
+			})
+
+			it("reports failure and closes issue", func() {
+				i.On("CreateComment", mock.Anything, "test-owner", "test-repository", 1, &github.IssueComment{
+					Body: github.Ptr(process.FormatSteps([]process.Step{
+						{Name: "Compute Metadata", Status: process.StepFailed, Problems: index.Problems{{
+							Message: "invalid id test-namespace",
+							Remedy:  "`id` must be in `{namespace}/{name}` format, using only letters, numbers, `.`, `-`, and `/`.",
+						}, {
+							Message: "address must be set",
+							Remedy:  "`addr` must be an image reference in digest form `{host}/{repository}@sha256:{digest}`.",
+						}}},
+						{Name: "Verify Namespace Owner", Status: process.StepSkipped},
+						{Name: "Verify Buildpackage", Status: process.StepSkipped},
+						{Name: "Update Index", Status: process.StepSkipped},
+					})),
+				}).Return(&github.IssueComment{}, nil, nil)
+				i.On("AddLabelsToIssue", mock.Anything, "test-owner", "test-repository", 1, []string{index.RequestFailureLabel}).
+					Return(nil, nil, nil)
+
+				Expect(process.ProcessRequest(tk, g, i, o, p, r, imageFn, s)).
+					To(MatchError("::error ::Registry request test-html-url failed"))
+			})
+		})
+	}, spec.Report(report.Terminal{}))
+}
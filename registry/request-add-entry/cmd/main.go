@@ -21,6 +21,7 @@ import (
 	"os"
 	"time"
 
+	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/google/go-github/v89/github"
 	"gopkg.in/retry.v1"
 
@@ -51,7 +52,7 @@ func main() {
 		},
 	)
 
-	if err := entry.RequestAddEntry(tk, gh.Issues, strategy); err != nil {
+	if err := entry.RequestAddEntry(tk, gh.Issues, remote.Image, strategy); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
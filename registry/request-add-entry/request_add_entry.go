@@ -19,26 +19,35 @@ package entry
 import (
 	"context"
 	"fmt"
+	"strconv"
 
 	"github.com/google/go-github/v89/github"
 	"github.com/pelletier/go-toml/v2"
 	"gopkg.in/retry.v1"
 
+	verify "github.com/buildpacks/github-actions/buildpackage/verify-metadata"
 	"github.com/buildpacks/github-actions/internal/toolkit"
 	"github.com/buildpacks/github-actions/registry/internal/index"
 	"github.com/buildpacks/github-actions/registry/internal/services"
 )
 
-func RequestAddEntry(tk toolkit.Toolkit, issues services.IssuesService, strategy retry.Strategy) error {
+func RequestAddEntry(tk toolkit.Toolkit, issues services.IssuesService, imageFn verify.ImageFunction, strategy retry.Strategy) error {
 	c, err := parseConfig(tk)
 	if err != nil {
 		return err
 	}
 
+	if c.VerifyImage {
+		if err := verify.VerifyMetadata(tk, imageFn); err != nil {
+			return err
+		}
+	}
+
 	body, err := toml.Marshal(index.Request{
-		ID:      c.ID,
-		Version: c.Version,
-		Address: c.Address,
+		ID:         c.ID,
+		Version:    c.Version,
+		Address:    c.Address,
+		PreRelease: c.PreRelease,
 	})
 	if err != nil {
 		return toolkit.FailedErrorf("unable to marshal to TOML\n%w", err)
@@ -62,9 +71,11 @@ func RequestAddEntry(tk toolkit.Toolkit, issues services.IssuesService, strategy
 }
 
 type config struct {
-	ID      string
-	Version string
-	Address string
+	ID          string
+	Version     string
+	Address     string
+	PreRelease  bool
+	VerifyImage bool
 }
 
 func parseConfig(tk toolkit.Toolkit) (config, error) {
@@ -88,5 +99,17 @@ func parseConfig(tk toolkit.Toolkit) (config, error) {
 		return config{}, toolkit.FailedError("address must be set")
 	}
 
+	if s, ok := tk.GetInput("pre-release"); ok {
+		if t, err := strconv.ParseBool(s); err == nil {
+			c.PreRelease = t
+		}
+	}
+
+	if s, ok := tk.GetInput("verify-image"); ok {
+		if t, err := strconv.ParseBool(s); err == nil {
+			c.VerifyImage = t
+		}
+	}
+
 	return c, nil
 }
@@ -20,6 +20,10 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/fake"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/google/go-github/v89/github"
 	. "github.com/onsi/gomega"
 	"github.com/pelletier/go-toml/v2"
@@ -28,6 +32,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"gopkg.in/retry.v1"
 
+	verify "github.com/buildpacks/github-actions/buildpackage/verify-metadata"
 	"github.com/buildpacks/github-actions/internal/toolkit"
 	"github.com/buildpacks/github-actions/registry/internal/index"
 	"github.com/buildpacks/github-actions/registry/internal/services"
@@ -36,50 +41,99 @@ import (
 
 func TestRequestAddEntry(t *testing.T) {
 	spec.Run(t, "request-add-entry", func(t *testing.T, context spec.G, it spec.S) {
+		const address = "host.com:443/repository/image@sha256:133f2117e15569ca59645eddad78f4a6a675c435f9614e4b137364274f3a7614"
+
 		var (
 			Expect = NewWithT(t).Expect
 
 			i  = &services.MockIssuesService{}
 			s  = retry.LimitCount(2, retry.Regular{Min: 2})
 			tk = &toolkit.MockToolkit{}
+
+			image   = &fake.FakeImage{}
+			imageFn = func(name.Reference, ...remote.Option) (v1.Image, error) { return image, nil }
 		)
 
 		it.Before(func() {
 			tk.On("GetInput", "id").Return("test-namespace/test-name", true)
 			tk.On("GetInput", "version").Return("test-version", true)
-			tk.On("GetInput", "address").Return("test-address", true)
+			tk.On("GetInput", "pre-release").Return("", false)
+		})
+
+		context("image is not verified", func() {
+			it.Before(func() {
+				tk.On("GetInput", "address").Return("test-address", true)
+				tk.On("GetInput", "verify-image").Return("", false)
+
+				b, err := toml.Marshal(index.Request{
+					ID:      "test-namespace/test-name",
+					Version: "test-version",
+					Address: "test-address",
+				})
+				Expect(err).NotTo(HaveOccurred())
 
-			b, err := toml.Marshal(index.Request{
-				ID:      "test-namespace/test-name",
-				Version: "test-version",
-				Address: "test-address",
+				i.On("Create", mock.Anything, "buildpacks", "registry-index", &github.IssueRequest{
+					Title: github.Ptr("ADD test-namespace/test-name@test-version"),
+					Body:  github.Ptr(fmt.Sprintf("```\n%s\n```", string(b))),
+				}).Return(&github.Issue{
+					Number:  github.Ptr(1),
+					HTMLURL: github.Ptr("test-html-url"),
+				}, nil, nil)
+			})
+
+			it("add entry succeeds", func() {
+				i.On("Get", mock.Anything, "buildpacks", "registry-index", 1).Return(&github.Issue{
+					Labels: []*github.Label{{Name: github.Ptr(index.RequestSuccessLabel)}},
+				}, nil, nil)
+
+				Expect(entry.RequestAddEntry(tk, i, imageFn, s)).To(Succeed())
 			})
-			Expect(err).NotTo(HaveOccurred())
-
-			i.On("Create", mock.Anything, "buildpacks", "registry-index", &github.IssueRequest{
-				Title: github.Ptr("ADD test-namespace/test-name@test-version"),
-				Body:  github.Ptr(fmt.Sprintf("```\n%s\n```", string(b))),
-			}).Return(&github.Issue{
-				Number:  github.Ptr(1),
-				HTMLURL: github.Ptr("test-html-url"),
-			}, nil, nil)
-		})
 
-		it("add entry succeeds", func() {
-			i.On("Get", mock.Anything, "buildpacks", "registry-index", 1).Return(&github.Issue{
-				Labels: []*github.Label{{Name: github.Ptr(index.RequestSuccessLabel)}},
-			}, nil, nil)
+			it("add entry fails", func() {
+				i.On("Get", mock.Anything, "buildpacks", "registry-index", 1).Return(&github.Issue{
+					Labels: []*github.Label{{Name: github.Ptr(index.RequestFailureLabel)}},
+				}, nil, nil)
 
-			Expect(entry.RequestAddEntry(tk, i, s)).To(Succeed())
+				Expect(entry.RequestAddEntry(tk, i, imageFn, s)).
+					To(MatchError("::error ::Registry request test-html-url failed"))
+			})
 		})
 
-		it("add entry fails", func() {
-			i.On("Get", mock.Anything, "buildpacks", "registry-index", 1).Return(&github.Issue{
-				Labels: []*github.Label{{Name: github.Ptr(index.RequestFailureLabel)}},
-			}, nil, nil)
+		context("image is verified", func() {
+			it.Before(func() {
+				tk.On("GetInput", "address").Return(address, true)
+				tk.On("GetInput", "verify-image").Return("true", true)
+			})
+
+			it("fails before creating issue if image does not match", func() {
+				image.ConfigFileReturns(&v1.ConfigFile{
+					Config: v1.Config{
+						Labels: map[string]string{verify.MetadataLabel: `{ "id": "test-namespace/test-name", "version": "another-version" }`},
+					},
+				}, nil)
+
+				Expect(entry.RequestAddEntry(tk, i, imageFn, s)).
+					To(MatchError("::error ::invalid version in buildpackage: expected test-version, found another-version"))
+				i.AssertNotCalled(t, "Create", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			})
+
+			it("creates issue if image matches", func() {
+				image.ConfigFileReturns(&v1.ConfigFile{
+					Config: v1.Config{
+						Labels: map[string]string{verify.MetadataLabel: `{ "id": "test-namespace/test-name", "version": "test-version" }`},
+					},
+				}, nil)
 
-			Expect(entry.RequestAddEntry(tk, i, s)).
-				To(MatchError("::error ::Registry request test-html-url failed"))
+				i.On("Create", mock.Anything, "buildpacks", "registry-index", mock.Anything).Return(&github.Issue{
+					Number:  github.Ptr(1),
+					HTMLURL: github.Ptr("test-html-url"),
+				}, nil, nil)
+				i.On("Get", mock.Anything, "buildpacks", "registry-index", 1).Return(&github.Issue{
+					Labels: []*github.Label{{Name: github.Ptr(index.RequestSuccessLabel)}},
+				}, nil, nil)
+
+				Expect(entry.RequestAddEntry(tk, i, imageFn, s)).To(Succeed())
+			})
 		})
 	}, spec.Report(report.Terminal{}))
 }
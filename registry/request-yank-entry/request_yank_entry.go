@@ -19,7 +19,9 @@ package entry
 import (
 	"context"
 	"fmt"
+	"strconv"
 
+	"github.com/Masterminds/semver/v3"
 	"github.com/google/go-github/v89/github"
 	"github.com/pelletier/go-toml/v2"
 	"gopkg.in/retry.v1"
@@ -36,16 +38,31 @@ func RequestYankEntry(tk toolkit.Toolkit, issues services.IssuesService, strateg
 	}
 
 	body, err := toml.Marshal(index.Request{
-		ID:      c.ID,
-		Version: c.Version,
-		Yank:    true,
+		ID:          c.ID,
+		Version:     c.Version,
+		Range:       c.Range,
+		Force:       c.Force,
+		Yank:        !c.Deprecate,
+		Deprecate:   c.Deprecate,
+		Reason:      c.Reason,
+		Replacement: c.Replacement,
 	})
 	if err != nil {
 		return toolkit.FailedErrorf("unable to marshal to TOML\n%w", err)
 	}
 
+	target := c.Version
+	if c.Range != "" {
+		target = c.Range
+	}
+
+	verb := "YANK"
+	if c.Deprecate {
+		verb = "DEPRECATE"
+	}
+
 	req := &github.IssueRequest{
-		Title: github.Ptr(fmt.Sprintf("YANK %s@%s", c.ID, c.Version)),
+		Title: github.Ptr(fmt.Sprintf("%s %s@%s", verb, c.ID, target)),
 		Body:  github.Ptr(fmt.Sprintf("```\n%s\n```", string(body))),
 	}
 
@@ -62,8 +79,13 @@ func RequestYankEntry(tk toolkit.Toolkit, issues services.IssuesService, strateg
 }
 
 type config struct {
-	ID      string
-	Version string
+	ID          string
+	Version     string
+	Range       string
+	Force       bool
+	Deprecate   bool
+	Reason      string
+	Replacement string
 }
 
 func parseConfig(tk toolkit.Toolkit) (config, error) {
@@ -77,9 +99,37 @@ func parseConfig(tk toolkit.Toolkit) (config, error) {
 		return config{}, toolkit.FailedError("id must be set")
 	}
 
-	c.Version, ok = tk.GetInput("version")
-	if !ok {
-		return config{}, toolkit.FailedError("version must be set")
+	c.Version, _ = tk.GetInput("version")
+
+	if s, ok := tk.GetInput("range"); ok && s != "" {
+		if _, err := semver.NewConstraint(s); err != nil {
+			return config{}, toolkit.FailedErrorf("invalid range %s\n%w", s, err)
+		}
+		c.Range = s
+	}
+
+	if c.Version == "" && c.Range == "" {
+		return config{}, toolkit.FailedError("version or range must be set")
+	}
+
+	if s, ok := tk.GetInput("force"); ok {
+		if t, err := strconv.ParseBool(s); err == nil {
+			c.Force = t
+		}
+	}
+
+	if s, ok := tk.GetInput("deprecate"); ok {
+		if t, err := strconv.ParseBool(s); err == nil {
+			c.Deprecate = t
+		}
+	}
+
+	if s, ok := tk.GetInput("reason"); ok {
+		c.Reason = s
+	}
+
+	if s, ok := tk.GetInput("replacement-version"); ok {
+		c.Replacement = s
 	}
 
 	return c, nil
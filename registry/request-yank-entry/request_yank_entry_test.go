@@ -47,38 +47,123 @@ func TestRequestYankEntry(t *testing.T) {
 		it.Before(func() {
 			tk.On("GetInput", "id").Return("test-namespace/test-name", true)
 			tk.On("GetInput", "version").Return("test-version", true)
+		})
+
+		context("yank", func() {
+			it.Before(func() {
+				tk.On("GetInput", "deprecate").Return("", false)
+				tk.On("GetInput", "reason").Return("", false)
+				tk.On("GetInput", "replacement-version").Return("", false)
+				tk.On("GetInput", "range").Return("", false)
+				tk.On("GetInput", "force").Return("", false)
+
+				b, err := toml.Marshal(index.Request{
+					ID:      "test-namespace/test-name",
+					Version: "test-version",
+					Yank:    true,
+				})
+				Expect(err).NotTo(HaveOccurred())
+
+				i.On("Create", mock.Anything, "buildpacks", "registry-index", &github.IssueRequest{
+					Title: github.Ptr("YANK test-namespace/test-name@test-version"),
+					Body:  github.Ptr(fmt.Sprintf("```\n%s\n```", string(b))),
+				}).Return(&github.Issue{
+					Number:  github.Ptr(1),
+					HTMLURL: github.Ptr("test-html-url"),
+				}, nil, nil)
+			})
+
+			it("yank entry succeeds", func() {
+				i.On("Get", mock.Anything, "buildpacks", "registry-index", 1).Return(&github.Issue{
+					Labels: []*github.Label{{Name: github.Ptr(index.RequestSuccessLabel)}},
+				}, nil, nil)
 
-			b, err := toml.Marshal(index.Request{
-				ID:      "test-namespace/test-name",
-				Version: "test-version",
-				Yank:    true,
+				Expect(entry.RequestYankEntry(tk, i, s)).To(Succeed())
+			})
+
+			it("yank entry fails", func() {
+				i.On("Get", mock.Anything, "buildpacks", "registry-index", 1).Return(&github.Issue{
+					Labels: []*github.Label{{Name: github.Ptr(index.RequestFailureLabel)}},
+				}, nil, nil)
+
+				Expect(entry.RequestYankEntry(tk, i, s)).
+					To(MatchError("::error ::Registry request test-html-url failed"))
 			})
-			Expect(err).NotTo(HaveOccurred())
-
-			i.On("Create", mock.Anything, "buildpacks", "registry-index", &github.IssueRequest{
-				Title: github.Ptr("YANK test-namespace/test-name@test-version"),
-				Body:  github.Ptr(fmt.Sprintf("```\n%s\n```", string(b))),
-			}).Return(&github.Issue{
-				Number:  github.Ptr(1),
-				HTMLURL: github.Ptr("test-html-url"),
-			}, nil, nil)
 		})
 
-		it("yank entry succeeds", func() {
-			i.On("Get", mock.Anything, "buildpacks", "registry-index", 1).Return(&github.Issue{
-				Labels: []*github.Label{{Name: github.Ptr(index.RequestSuccessLabel)}},
-			}, nil, nil)
+		context("deprecate", func() {
+			it.Before(func() {
+				tk.On("GetInput", "deprecate").Return("true", true)
+				tk.On("GetInput", "reason").Return("test-reason", true)
+				tk.On("GetInput", "replacement-version").Return("test-replacement", true)
+				tk.On("GetInput", "range").Return("", false)
+				tk.On("GetInput", "force").Return("", false)
+			})
+
+			it("requests deprecation with reason and replacement", func() {
+				b, err := toml.Marshal(index.Request{
+					ID:          "test-namespace/test-name",
+					Version:     "test-version",
+					Deprecate:   true,
+					Reason:      "test-reason",
+					Replacement: "test-replacement",
+				})
+				Expect(err).NotTo(HaveOccurred())
 
-			Expect(entry.RequestYankEntry(tk, i, s)).To(Succeed())
+				i.On("Create", mock.Anything, "buildpacks", "registry-index", &github.IssueRequest{
+					Title: github.Ptr("DEPRECATE test-namespace/test-name@test-version"),
+					Body:  github.Ptr(fmt.Sprintf("```\n%s\n```", string(b))),
+				}).Return(&github.Issue{
+					Number:  github.Ptr(1),
+					HTMLURL: github.Ptr("test-html-url"),
+				}, nil, nil)
+				i.On("Get", mock.Anything, "buildpacks", "registry-index", 1).Return(&github.Issue{
+					Labels: []*github.Label{{Name: github.Ptr(index.RequestSuccessLabel)}},
+				}, nil, nil)
+
+				Expect(entry.RequestYankEntry(tk, i, s)).To(Succeed())
+			})
 		})
 
-		it("yank entry fails", func() {
-			i.On("Get", mock.Anything, "buildpacks", "registry-index", 1).Return(&github.Issue{
-				Labels: []*github.Label{{Name: github.Ptr(index.RequestFailureLabel)}},
-			}, nil, nil)
+		context("range", func() {
+			it.Before(func() {
+				tk.On("GetInput", "deprecate").Return("", false)
+				tk.On("GetInput", "reason").Return("", false)
+				tk.On("GetInput", "replacement-version").Return("", false)
+				tk.On("GetInput", "force").Return("", false)
+			})
+
+			it("requests yank of range", func() {
+				tk.On("GetInput", "range").Return(">=1.0.0 <1.4.2", true)
+
+				b, err := toml.Marshal(index.Request{
+					ID:      "test-namespace/test-name",
+					Version: "test-version",
+					Range:   ">=1.0.0 <1.4.2",
+					Yank:    true,
+				})
+				Expect(err).NotTo(HaveOccurred())
 
-			Expect(entry.RequestYankEntry(tk, i, s)).
-				To(MatchError("::error ::Registry request test-html-url failed"))
+				i.On("Create", mock.Anything, "buildpacks", "registry-index", &github.IssueRequest{
+					Title: github.Ptr("YANK test-namespace/test-name@>=1.0.0 <1.4.2"),
+					Body:  github.Ptr(fmt.Sprintf("```\n%s\n```", string(b))),
+				}).Return(&github.Issue{
+					Number:  github.Ptr(1),
+					HTMLURL: github.Ptr("test-html-url"),
+				}, nil, nil)
+				i.On("Get", mock.Anything, "buildpacks", "registry-index", 1).Return(&github.Issue{
+					Labels: []*github.Label{{Name: github.Ptr(index.RequestSuccessLabel)}},
+				}, nil, nil)
+
+				Expect(entry.RequestYankEntry(tk, i, s)).To(Succeed())
+			})
+
+			it("fails if range is invalid", func() {
+				tk.On("GetInput", "range").Return("test-range", true)
+
+				Expect(entry.RequestYankEntry(tk, i, s)).
+					To(MatchError(HavePrefix("::error ::invalid range test-range")))
+			})
 		})
 	}, spec.Report(report.Terminal{}))
 }
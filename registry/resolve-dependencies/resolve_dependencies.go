@@ -0,0 +1,322 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resolve
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/pelletier/go-toml/v2"
+
+	verify "github.com/buildpacks/github-actions/buildpackage/verify-metadata"
+	"github.com/buildpacks/github-actions/internal/toolkit"
+	"github.com/buildpacks/github-actions/registry/internal/index"
+)
+
+const (
+	KindImage    = "image"
+	KindLocal    = "local"
+	KindRegistry = "registry"
+
+	RegistryPrefix = "urn:cnb:registry:"
+)
+
+// Package is the part of a package.toml that describes its dependencies.
+type Package struct {
+	Dependencies []struct {
+		URI   string `toml:"uri"`
+		Image string `toml:"image"`
+	} `toml:"dependencies"`
+}
+
+type Dependency struct {
+	URI     string `json:"uri"`
+	Kind    string `json:"kind"`
+	ID      string `json:"id,omitempty"`
+	Version string `json:"version,omitempty"`
+	Address string `json:"address,omitempty"`
+	Path    string `json:"path,omitempty"`
+	Digest  string `json:"digest,omitempty"`
+}
+
+func ResolveDependencies(tk toolkit.Toolkit, imageFn verify.ImageFunction) error {
+	c, err := parseConfig(tk)
+	if err != nil {
+		return err
+	}
+
+	b, err := os.ReadFile(c.Path)
+	if err != nil {
+		return toolkit.FailedErrorf("unable to read %s\n%w", c.Path, err)
+	}
+
+	var p Package
+	if err := toml.Unmarshal(b, &p); err != nil {
+		return toolkit.FailedErrorf("unable to decode %s\n%w", c.Path, err)
+	}
+
+	var (
+		dependencies = []Dependency{}
+		digests      = []string{}
+		failed       int
+	)
+
+	for _, d := range p.Dependencies {
+		uri := d.URI
+		if uri == "" && d.Image != "" {
+			uri = fmt.Sprintf("docker://%s", d.Image)
+		}
+
+		var dep Dependency
+		switch {
+		case strings.HasPrefix(uri, "docker://"):
+			dep, err = resolveImage(uri, imageFn)
+		case strings.HasPrefix(uri, RegistryPrefix):
+			dep, err = resolveRegistry(tk, uri, c.Index)
+		case uri == "":
+			err = fmt.Errorf("dependency must have a uri or an image")
+		case strings.HasPrefix(uri, "file://") || !strings.Contains(uri, "://"):
+			dep, err = resolveLocal(uri, filepath.Dir(c.Path))
+		default:
+			err = fmt.Errorf("unsupported dependency uri %s, must be docker://, %s, or a local path", uri, RegistryPrefix)
+		}
+
+		if err != nil {
+			tk.Errorf("%s", err)
+			failed++
+			continue
+		}
+
+		fmt.Printf("Resolved %s to %s\n", dep.URI, dep.Digest)
+		dependencies = append(dependencies, dep)
+		if dep.Digest != "" {
+			digests = append(digests, dep.Digest)
+		}
+	}
+
+	if failed > 0 {
+		return toolkit.FailedErrorf("%d of %d dependencies of %s could not be resolved", failed, len(p.Dependencies), c.Path)
+	}
+
+	b, err = json.Marshal(dependencies)
+	if err != nil {
+		return toolkit.FailedErrorf("unable to marshal dependencies\n%w", err)
+	}
+	tk.SetOutput("dependencies", string(b))
+
+	b, err = json.Marshal(digests)
+	if err != nil {
+		return toolkit.FailedErrorf("unable to marshal digests\n%w", err)
+	}
+	tk.SetOutput("digests", string(b))
+
+	return nil
+}
+
+func resolveImage(uri string, imageFn verify.ImageFunction) (Dependency, error) {
+	ref, err := name.ParseReference(strings.TrimPrefix(uri, "docker://"))
+	if err != nil {
+		return Dependency{}, fmt.Errorf("unable to parse %s as image reference\n%w", uri, err)
+	}
+
+	image, err := imageFn(ref)
+	if err != nil {
+		return Dependency{}, fmt.Errorf("unable to retrieve image %s\n%w", ref, err)
+	}
+
+	digest, err := image.Digest()
+	if err != nil {
+		return Dependency{}, fmt.Errorf("unable to compute digest of %s\n%w", ref, err)
+	}
+
+	dep := Dependency{
+		URI:     uri,
+		Kind:    KindImage,
+		Address: ref.Context().Digest(digest.String()).Name(),
+		Digest:  digest.String(),
+	}
+
+	if configFile, err := image.ConfigFile(); err == nil {
+		var m struct {
+			ID      string `json:"id"`
+			Version string `json:"version"`
+		}
+		if err := json.Unmarshal([]byte(configFile.Config.Labels[verify.MetadataLabel]), &m); err == nil {
+			dep.ID = m.ID
+			dep.Version = m.Version
+		}
+	}
+
+	return dep, nil
+}
+
+// resolveRegistry resolves a urn:cnb:registry:{namespace}/{name}[@{version}] against the registry index files below
+// dir.  Without a version, the highest version that is not yanked is resolved.
+func resolveRegistry(tk toolkit.Toolkit, uri string, dir string) (Dependency, error) {
+	if dir == "" {
+		return Dependency{}, fmt.Errorf("index must be set to resolve %s", uri)
+	}
+
+	id, version, _ := strings.Cut(strings.TrimPrefix(uri, RegistryPrefix), "@")
+
+	g := index.ValidRequestId.FindStringSubmatch(id)
+	if g == nil {
+		return Dependency{}, fmt.Errorf("invalid id %s in %s", id, uri)
+	}
+
+	b, err := os.ReadFile(filepath.Join(dir, index.Path(g[1], g[2])))
+	if errors.Is(err, fs.ErrNotExist) {
+		return Dependency{}, fmt.Errorf("%s is not in the registry index", id)
+	} else if err != nil {
+		return Dependency{}, fmt.Errorf("unable to read index of %s\n%w", id, err)
+	}
+
+	entries, err := index.UnmarshalEntries(string(b))
+	if err != nil {
+		return Dependency{}, fmt.Errorf("unable to unmarshal index of %s\n%w", id, err)
+	}
+
+	var entry *index.Entry
+	if version == "" {
+		entry = latest(entries)
+		if entry == nil {
+			return Dependency{}, fmt.Errorf("%s has no versions that are not yanked", id)
+		}
+	} else {
+		for i := range entries {
+			if entries[i].Version == version {
+				entry = &entries[i]
+			}
+		}
+
+		if entry == nil {
+			return Dependency{}, fmt.Errorf("version %s of %s is not in the registry index", version, id)
+		}
+
+		if entry.Yanked {
+			if entry.Reason != "" {
+				return Dependency{}, fmt.Errorf("version %s of %s is yanked: %s", version, id, entry.Reason)
+			}
+			return Dependency{}, fmt.Errorf("version %s of %s is yanked", version, id)
+		}
+	}
+
+	if entry.Deprecated {
+		tk.Warningf("version %s of %s is deprecated", entry.Version, id)
+	}
+
+	digest, err := name.NewDigest(entry.Address)
+	if err != nil {
+		return Dependency{}, fmt.Errorf("address %s of %s@%s is not in digest form", entry.Address, id, entry.Version)
+	}
+
+	return Dependency{
+		URI:     uri,
+		Kind:    KindRegistry,
+		ID:      id,
+		Version: entry.Version,
+		Address: entry.Address,
+		Digest:  digest.DigestStr(),
+	}, nil
+}
+
+func latest(entries []index.Entry) *index.Entry {
+	var (
+		entry   *index.Entry
+		highest *semver.Version
+	)
+
+	for i, e := range entries {
+		if e.Yanked {
+			continue
+		}
+
+		v, err := semver.NewVersion(e.Version)
+		if err != nil {
+			continue
+		}
+
+		if highest == nil || v.GreaterThan(highest) {
+			entry = &entries[i]
+			highest = v
+		}
+	}
+
+	return entry
+}
+
+// resolveLocal resolves a path, relative to the directory of package.toml unless absolute.  Files are resolved to the
+// digest of their content and directories have no digest.
+func resolveLocal(uri string, dir string) (Dependency, error) {
+	path := strings.TrimPrefix(uri, "file://")
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return Dependency{}, fmt.Errorf("dependency %s does not exist at %s", uri, path)
+	}
+
+	dep := Dependency{URI: uri, Kind: KindLocal, Path: path}
+	if info.IsDir() {
+		return dep, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return Dependency{}, fmt.Errorf("unable to open %s\n%w", path, err)
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return Dependency{}, fmt.Errorf("unable to read %s\n%w", path, err)
+	}
+
+	dep.Digest = fmt.Sprintf("sha256:%s", hex.EncodeToString(h.Sum(nil)))
+	return dep, nil
+}
+
+type config struct {
+	Path  string
+	Index string
+}
+
+func parseConfig(tk toolkit.Toolkit) (config, error) {
+	c := config{Path: "package.toml"}
+
+	if s, ok := tk.GetInput("path"); ok && s != "" {
+		c.Path = s
+	}
+
+	if s, ok := tk.GetInput("index"); ok {
+		c.Index = s
+	}
+
+	return c, nil
+}
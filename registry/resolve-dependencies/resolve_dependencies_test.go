@@ -0,0 +1,218 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package resolve_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/buildpacks/github-actions/internal/testutil"
+	"github.com/buildpacks/github-actions/internal/toolkit"
+	"github.com/buildpacks/github-actions/registry/internal/index"
+	resolve "github.com/buildpacks/github-actions/registry/resolve-dependencies"
+)
+
+func TestResolveDependencies(t *testing.T) {
+	spec.Run(t, "resolve-dependencies", func(t *testing.T, context spec.G, it spec.S) {
+		var (
+			Expect           = NewWithT(t).Expect
+			ExpectWithOffset = NewWithT(t).ExpectWithOffset
+
+			dir  string
+			host string
+			path string
+			tk   = &toolkit.MockToolkit{}
+		)
+
+		push := func(repository string, id string, version string) (string, string) {
+			return testutil.Push(t, host, repository, version, testutil.BuildpackageLabels(id, version))
+		}
+
+		write := func(file string, content string) {
+			testutil.WriteFile(t, file, content)
+		}
+
+		entries := func(entries ...index.Entry) {
+			s, err := index.MarshalEntries(entries)
+			ExpectWithOffset(1, err).NotTo(HaveOccurred())
+			write(filepath.Join(dir, "index", index.Path(entries[0].Namespace, entries[0].Name)), s)
+		}
+
+		dependencies := func() []resolve.Dependency {
+			var d []resolve.Dependency
+			ExpectWithOffset(1, json.Unmarshal([]byte(testutil.Output(tk, "dependencies")), &d)).To(Succeed())
+			return d
+		}
+
+		it.Before(func() {
+			host = testutil.Registry(t)
+			dir = t.TempDir()
+			path = filepath.Join(dir, "package.toml")
+
+			tk.On("GetInput", "path").Return(path, true)
+			tk.On("GetInput", "index").Return(filepath.Join(dir, "index"), true)
+			tk.On("SetOutput", mock.Anything, mock.Anything)
+			tk.On("Errorf", mock.Anything, mock.Anything)
+			tk.On("Warningf", mock.Anything, mock.Anything, mock.Anything)
+		})
+
+		it("resolves dependencies", func() {
+			image, imageDigest := push("test-image", "test-namespace/test-image", "1.0.0")
+
+			address := fmt.Sprintf("%s/test-name@sha256:%s", host, strings.Repeat("a", 64))
+			entries(
+				index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "1.0.0", Address: address},
+				index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "1.1.0", Address: address, Deprecated: true},
+				index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "2.0.0", Address: address, Yanked: true},
+			)
+
+			write(filepath.Join(dir, "local", "buildpack.toml"), "[buildpack]\nid = \"test-local\"\n")
+			write(filepath.Join(dir, "test.cnb"), "test-content")
+
+			write(path, fmt.Sprintf(`[buildpack]
+uri = "."
+
+[[dependencies]]
+uri = "docker://%s"
+
+[[dependencies]]
+uri = "urn:cnb:registry:test-namespace/test-name@1.0.0"
+
+[[dependencies]]
+uri = "urn:cnb:registry:test-namespace/test-name"
+
+[[dependencies]]
+uri = "local"
+
+[[dependencies]]
+uri = "file://%s"
+`, image, filepath.Join(dir, "test.cnb")))
+
+			Expect(resolve.ResolveDependencies(tk, remote.Image)).To(Succeed())
+
+			Expect(dependencies()).To(Equal([]resolve.Dependency{
+				{
+					URI:     "docker://" + image,
+					Kind:    resolve.KindImage,
+					ID:      "test-namespace/test-image",
+					Version: "1.0.0",
+					Address: fmt.Sprintf("%s/test-image@%s", host, imageDigest),
+					Digest:  imageDigest,
+				},
+				{
+					URI:     "urn:cnb:registry:test-namespace/test-name@1.0.0",
+					Kind:    resolve.KindRegistry,
+					ID:      "test-namespace/test-name",
+					Version: "1.0.0",
+					Address: address,
+					Digest:  "sha256:" + strings.Repeat("a", 64),
+				},
+				{
+					URI:     "urn:cnb:registry:test-namespace/test-name",
+					Kind:    resolve.KindRegistry,
+					ID:      "test-namespace/test-name",
+					Version: "1.1.0",
+					Address: address,
+					Digest:  "sha256:" + strings.Repeat("a", 64),
+				},
+				{
+					URI:  "local",
+					Kind: resolve.KindLocal,
+					Path: filepath.Join(dir, "local"),
+				},
+				{
+					URI:    "file://" + filepath.Join(dir, "test.cnb"),
+					Kind:   resolve.KindLocal,
+					Path:   filepath.Join(dir, "test.cnb"),
+					Digest: "sha256:0a3666a0710c08aa6d0de92ce72beeb5b93124cce1bf3701c9d6cdeb543cb73e",
+				},
+			}))
+
+			tk.AssertCalled(t, "SetOutput", "digests", fmt.Sprintf(`["%s","sha256:%s","sha256:%s","sha256:0a3666a0710c08aa6d0de92ce72beeb5b93124cce1bf3701c9d6cdeb543cb73e"]`,
+				imageDigest, strings.Repeat("a", 64), strings.Repeat("a", 64)))
+			tk.AssertCalled(t, "Warningf", "version %s of %s is deprecated", "1.1.0", "test-namespace/test-name")
+		})
+
+		it("resolves image keys", func() {
+			image, imageDigest := push("test-image", "test-namespace/test-image", "1.0.0")
+			write(path, fmt.Sprintf("[[dependencies]]\nimage = \"%s\"\n", image))
+
+			Expect(resolve.ResolveDependencies(tk, remote.Image)).To(Succeed())
+			Expect(dependencies()).To(HaveLen(1))
+			Expect(dependencies()[0].Digest).To(Equal(imageDigest))
+		})
+
+		it("fails on yanked and absent dependencies", func() {
+			address := fmt.Sprintf("%s/test-name@sha256:%s", host, strings.Repeat("a", 64))
+			entries(
+				index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "1.0.0", Address: address, Yanked: true, Reason: "test-reason"},
+			)
+
+			write(path, fmt.Sprintf(`[[dependencies]]
+uri = "urn:cnb:registry:test-namespace/test-name@1.0.0"
+
+[[dependencies]]
+uri = "urn:cnb:registry:test-namespace/test-name@1.1.0"
+
+[[dependencies]]
+uri = "urn:cnb:registry:test-namespace/test-name"
+
+[[dependencies]]
+uri = "urn:cnb:registry:test-namespace/other"
+
+[[dependencies]]
+uri = "docker://%s/missing:1.0.0"
+
+[[dependencies]]
+uri = "missing"
+
+[[dependencies]]
+uri = "https://example.com/test.tgz"
+`, host))
+
+			Expect(resolve.ResolveDependencies(tk, remote.Image)).
+				To(MatchError(fmt.Sprintf("::error ::7 of 7 dependencies of %s could not be resolved", path)))
+
+			message := func(i int) string {
+				var m []string
+				for _, c := range tk.Calls {
+					if c.Method == "Errorf" {
+						m = append(m, fmt.Sprint(c.Arguments[1]))
+					}
+				}
+				return m[i]
+			}
+
+			Expect(message(0)).To(Equal("version 1.0.0 of test-namespace/test-name is yanked: test-reason"))
+			Expect(message(1)).To(Equal("version 1.1.0 of test-namespace/test-name is not in the registry index"))
+			Expect(message(2)).To(Equal("test-namespace/test-name has no versions that are not yanked"))
+			Expect(message(3)).To(Equal("test-namespace/other is not in the registry index"))
+			Expect(message(4)).To(HavePrefix(fmt.Sprintf("unable to retrieve image %s/missing:1.0.0", host)))
+			Expect(message(5)).To(Equal(fmt.Sprintf("dependency missing does not exist at %s", filepath.Join(dir, "missing"))))
+			Expect(message(6)).To(Equal("unsupported dependency uri https://example.com/test.tgz, must be docker://, urn:cnb:registry:, or a local path"))
+		})
+	}, spec.Report(report.Terminal{}))
+}
@@ -21,23 +21,17 @@ import (
 	"os"
 	"time"
 
-	"github.com/google/go-github/v89/github"
 	"gopkg.in/retry.v1"
 
 	"github.com/buildpacks/github-actions/internal/toolkit"
+	"github.com/buildpacks/github-actions/registry/internal/credentials"
 	owner "github.com/buildpacks/github-actions/registry/verify-namespace-owner"
 )
 
 func main() {
 	tk := &toolkit.DefaultToolkit{}
 
-	t, ok := tk.GetInput("token")
-	if !ok {
-		fmt.Println(toolkit.FailedError("token must be specified"))
-		os.Exit(1)
-	}
-
-	gh, err := github.NewClient(github.WithAuthToken(t))
+	gh, err := credentials.NewClient(tk)
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
@@ -51,7 +45,7 @@ func main() {
 		},
 	)
 
-	if err := owner.VerifyNamespaceOwner(tk, gh.Organizations, gh.Repositories, strategy); err != nil {
+	if err := owner.VerifyNamespaceOwner(tk, gh.Git, gh.Issues, gh.Organizations, gh.Repositories, strategy); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
@@ -19,19 +19,21 @@ package owner
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"net/http"
 	"strconv"
 
 	"github.com/google/go-github/v89/github"
 	"gopkg.in/retry.v1"
 
 	"github.com/buildpacks/github-actions/internal/toolkit"
+	"github.com/buildpacks/github-actions/registry/internal/commit"
+	"github.com/buildpacks/github-actions/registry/internal/index"
 	"github.com/buildpacks/github-actions/registry/internal/namespace"
 	"github.com/buildpacks/github-actions/registry/internal/services"
 )
 
-func VerifyNamespaceOwner(tk toolkit.Toolkit, organizations services.OrganizationsService, repositories services.RepositoriesService, strategy retry.Strategy) error {
+func VerifyNamespaceOwner(tk toolkit.Toolkit, git services.GitService, issues services.IssuesService, organizations services.OrganizationsService, repositories services.RepositoriesService, strategy retry.Strategy) error {
 	c, err := parseConfig(tk)
 	if err != nil {
 		return err
@@ -42,46 +44,77 @@ func VerifyNamespaceOwner(tk toolkit.Toolkit, organizations services.Organizatio
 		return toolkit.FailedErrorf("unable to unmarshal user\n%w", err)
 	}
 
-	n, err := getNamespace(tk, c, user, repositories, strategy)
-	if err != nil {
+	var issue *github.Issue
+	if c.Issue != "" {
+		issue = &github.Issue{}
+		if err := json.Unmarshal([]byte(c.Issue), issue); err != nil {
+			return toolkit.FailedErrorf("unable to unmarshal issue\n%w", err)
+		}
+	}
+
+	committer := commit.Committer{Config: c.Commit, Git: git, Repositories: repositories}
+
+	n, err := getNamespace(tk, c, user, committer, repositories, strategy)
+	var problems index.Problems
+	if errors.As(err, &problems) {
+		return report(tk, issues, issue, problems)
+	} else if err != nil {
 		return err
 	}
 
 	if isBlockedNamespaces(config{}) {
-		return toolkit.FailedErrorf("The namespace '%s' is restricted.", c.Namespace)
+		return report(tk, issues, issue, index.Problems{{
+			Message: fmt.Sprintf("The namespace '%s' is restricted.", c.Namespace),
+			Remedy:  fmt.Sprintf("The `%s` namespace is reserved.  Use a namespace owned by you or your organization.", c.Namespace),
+		}})
 	}
 
-	if namespace.IsOwner(n.Owners, namespace.ByUser(*user.ID)) {
+	if ok, err := namespace.IsUserOwner(n.Owners, user, organizations); err != nil {
+		return toolkit.FailedError(err)
+	} else if ok {
 		fmt.Printf("Verified %s is an owner of %s\n", *user.Login, c.Namespace)
 		return nil
 	}
 
-	ids, err := listOrganizations(*user.Login, organizations)
-	if err != nil {
-		return toolkit.FailedErrorf("unable to list organizations for %s\n%w", *user.Login, err)
+	return report(tk, issues, issue, index.Problems{{
+		Message: fmt.Sprintf("%s is not an owner of %s", *user.Login, c.Namespace),
+		Remedy:  "Open the request from a GitHub account that owns the namespace, or that is a member of an organization that owns it.",
+	}})
+}
+
+// report comments on the registry request issue with problems, if both the issue and a client are available, and
+// returns problems.
+func report(tk toolkit.Toolkit, issues services.IssuesService, issue *github.Issue, problems index.Problems) error {
+	if issues == nil || issue == nil {
+		return problems
 	}
 
-	if namespace.IsOwner(n.Owners, namespace.ByOrganizations(ids)) {
-		fmt.Printf("Verified %s is an owner of %s\n", *user.Login, c.Namespace)
-		return nil
+	if owner, repository, err := index.IssueRepository(*issue); err != nil {
+		tk.Warningf("unable to report problems\n%s", err)
+	} else if err := index.ReportProblems(issues, owner, repository, issue.GetNumber(), problems); err != nil {
+		tk.Warningf("unable to report problems\n%s", err)
 	}
 
-	return toolkit.FailedErrorf("%s is not an owner of %s", *user.Login, c.Namespace)
+	return problems
 }
 
 type config struct {
+	Issue             string
 	User              string
 	Owner             string
 	Repository        string
 	Namespace         string
 	AddIfMissing      bool
+	URL               string
+	Commit            commit.Config
 	blockedNamespaces []string
 }
 
 func parseConfig(tk toolkit.Toolkit) (config, error) {
 	var (
-		c  = config{AddIfMissing: false}
-		ok bool
+		c   = config{AddIfMissing: false}
+		ok  bool
+		err error
 	)
 
 	c.User, ok = tk.GetInput("user")
@@ -116,17 +149,33 @@ func parseConfig(tk toolkit.Toolkit) (config, error) {
 		}
 	}
 
+	if s, ok := tk.GetInput("request-url"); ok {
+		c.URL = s
+	}
+
+	if s, ok := tk.GetInput("issue"); ok {
+		c.Issue = s
+	}
+
+	c.Commit, err = commit.ParseConfig(tk, "New Namespace: {ns}")
+	if err != nil {
+		return config{}, err
+	}
+
 	return c, nil
 }
 
-func getNamespace(tk toolkit.Toolkit, c config, user github.User, repositories services.RepositoriesService, strategy retry.Strategy) (namespace.Namespace, error) {
+func getNamespace(tk toolkit.Toolkit, c config, user github.User, committer commit.Committer, repositories services.RepositoriesService, strategy retry.Strategy) (namespace.Namespace, error) {
 	file := namespace.Path(c.Namespace)
 
 	for a := retry.Start(strategy, nil); a.Next(); {
-		content, _, resp, err := repositories.GetContents(context.Background(), c.Owner, c.Repository, file, nil)
-		if resp != nil && resp.StatusCode == http.StatusNotFound {
+		n, err := namespace.Read(repositories, c.Owner, c.Repository, c.Namespace)
+		if errors.Is(err, namespace.ErrNotFound) {
 			if !c.AddIfMissing {
-				return namespace.Namespace{}, toolkit.FailedErrorf("invalid namespace %s", c.Namespace)
+				return namespace.Namespace{}, index.Problems{{
+					Message: fmt.Sprintf("invalid namespace %s", c.Namespace),
+					Remedy:  "The namespace must be registered before buildpacks can be added to it.",
+				}}
 			}
 
 			b, err := json.Marshal(namespace.Namespace{Owners: []namespace.Owner{{ID: *user.ID, Type: namespace.UserType}}})
@@ -135,14 +184,13 @@ func getNamespace(tk toolkit.Toolkit, c config, user github.User, repositories s
 			}
 
 			tk.Debugf("creating new namespace: %s\n%s", file, b)
-			if _, resp, err := repositories.CreateFile(context.Background(), c.Owner, c.Repository, file, &github.RepositoryContentFileOptions{
-				Author: &github.CommitAuthor{
-					Name:  github.Ptr("buildpacks-bot"),
-					Email: github.Ptr("cncf-buildpacks-maintainers@lists.cncf.io"),
-				},
-				Message: github.Ptr(fmt.Sprintf("New Namespace: %s", c.Namespace)),
+			if err := committer.Commit(context.Background(), c.Owner, c.Repository, commit.File{
+				Path:    file,
 				Content: b,
-			}); resp != nil && resp.StatusCode == http.StatusConflict {
+			}, c.Commit.FormatMessage(commit.Values{
+				Namespace: c.Namespace,
+				URL:       c.URL,
+			})); errors.Is(err, commit.ErrConflict) {
 				tk.Warningf("retrying namespace update after conflict: %s", file)
 				continue
 			} else if err != nil {
@@ -153,17 +201,7 @@ func getNamespace(tk toolkit.Toolkit, c config, user github.User, repositories s
 			fmt.Printf("New Namespace: %s\n", c.Namespace)
 			continue
 		} else if err != nil {
-			return namespace.Namespace{}, toolkit.FailedErrorf("unable to read namespace %s\n%w", c.Namespace, err)
-		}
-
-		s, err := content.GetContent()
-		if err != nil {
-			return namespace.Namespace{}, toolkit.FailedErrorf("unable to get namespace content\n%w", err)
-		}
-
-		var n namespace.Namespace
-		if err := json.Unmarshal([]byte(s), &n); err != nil {
-			return namespace.Namespace{}, toolkit.FailedErrorf("unable to unmarshal owners\n%w", err)
+			return namespace.Namespace{}, toolkit.FailedError(err)
 		}
 
 		return n, nil
@@ -172,30 +210,6 @@ func getNamespace(tk toolkit.Toolkit, c config, user github.User, repositories s
 	return namespace.Namespace{}, toolkit.FailedError("timed out")
 }
 
-func listOrganizations(user string, organizations services.OrganizationsService) ([]int64, error) {
-	var ids []int64
-
-	opt := &github.ListOptions{PerPage: 100}
-
-	for {
-		orgs, rsp, err := organizations.List(context.Background(), user, opt)
-		if err != nil {
-			return nil, err
-		}
-
-		for _, o := range orgs {
-			ids = append(ids, *o.ID)
-		}
-
-		if rsp.NextPage == 0 {
-			break
-		}
-		opt.Page = rsp.NextPage
-	}
-
-	return ids, nil
-}
-
 func isBlockedNamespaces(c config) bool {
 	for _, name := range c.blockedNamespaces {
 		if c.Namespace == name {
@@ -30,6 +30,7 @@ import (
 	"gopkg.in/retry.v1"
 
 	"github.com/buildpacks/github-actions/internal/toolkit"
+	"github.com/buildpacks/github-actions/registry/internal/index"
 	"github.com/buildpacks/github-actions/registry/internal/namespace"
 	"github.com/buildpacks/github-actions/registry/internal/services"
 	owner "github.com/buildpacks/github-actions/registry/verify-namespace-owner"
@@ -41,6 +42,8 @@ func TestVerifyNamespaceOwner(t *testing.T) {
 			Expect           = NewWithT(t).Expect
 			ExpectWithOffset = NewWithT(t).ExpectWithOffset
 
+			g     = &services.MockGitService{}
+			i     = &services.MockIssuesService{}
 			o     = &services.MockOrganizationsService{}
 			r     = &services.MockRepositoriesService{}
 			rOpts *github.RepositoryContentGetOptions
@@ -62,10 +65,18 @@ func TestVerifyNamespaceOwner(t *testing.T) {
 			tk.On("GetInput", "namespace").Return("test-namespace", true)
 			tk.On("GetInput", "user").
 				Return(asJSONString(github.User{ID: github.Ptr(int64(1)), Login: github.Ptr("test-user")}), true)
+			tk.On("GetInput", "request-url").Return("", false)
+			tk.On("GetInput", "author-name").Return("", false)
+			tk.On("GetInput", "author-email").Return("", false)
+			tk.On("GetInput", "committer-name").Return("", false)
+			tk.On("GetInput", "committer-email").Return("", false)
+			tk.On("GetInput", "commit-message").Return("", false)
+			tk.On("GetInput", "signing-key").Return("", false)
 		})
 
 		context("unknown namespace", func() {
 			it.Before(func() {
+				tk.On("GetInput", "issue").Return("", false)
 				r.On("GetContents", mock.Anything, "test-owner", "test-repository", filepath.Join("v1", "test-namespace.json"), rOpts).
 					Return(nil, nil, &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, nil).
 					Once()
@@ -74,14 +85,14 @@ func TestVerifyNamespaceOwner(t *testing.T) {
 			it("fails if add-if-missing is false", func() {
 				tk.On("GetInput", "add-if-missing").Return("", false)
 				tk.On("GetInputList", "blocked_namespaces").Return([]string{"test-owner"}, false)
-				Expect(owner.VerifyNamespaceOwner(tk, o, r, s)).
+				Expect(owner.VerifyNamespaceOwner(tk, g, nil, o, r, s)).
 					To(MatchError("::error ::invalid namespace test-namespace"))
 			})
 
 			it("fails if namespace is blocked", func() {
 				tk.On("GetInput", "add-if-missing").Return("", false)
 				tk.On("GetInputList", "blocked_namespaces").Return([]string{"test-owner"}, false)
-				Expect(owner.VerifyNamespaceOwner(tk, o, r, s)).
+				Expect(owner.VerifyNamespaceOwner(tk, g, nil, o, r, s)).
 					To(MatchError("::error ::invalid namespace test-namespace"))
 			})
 
@@ -107,12 +118,13 @@ func TestVerifyNamespaceOwner(t *testing.T) {
 					Content: &github.RepositoryContent{Content: github.Ptr(c)},
 				}, nil, nil)
 
-				Expect(owner.VerifyNamespaceOwner(tk, o, r, s)).To(Succeed())
+				Expect(owner.VerifyNamespaceOwner(tk, g, nil, o, r, s)).To(Succeed())
 			})
 		})
 
 		context("user-owned namespace", func() {
 			it.Before(func() {
+				tk.On("GetInput", "issue").Return("", false)
 				tk.On("GetInput", "add-if-missing").Return("", false)
 				tk.On("GetInputList", "blocked_namespaces").Return([]string{"test-owner"}, false)
 				o.On("List", mock.Anything, "test-user", mock.Anything).
@@ -125,7 +137,7 @@ func TestVerifyNamespaceOwner(t *testing.T) {
 						Content: github.Ptr(asJSONString(namespace.Namespace{Owners: []namespace.Owner{{ID: 2, Type: namespace.UserType}}})),
 					}, nil, nil, nil)
 
-				Expect(owner.VerifyNamespaceOwner(tk, o, r, s)).
+				Expect(owner.VerifyNamespaceOwner(tk, g, nil, o, r, s)).
 					To(MatchError("::error ::test-user is not an owner of test-namespace"))
 			})
 
@@ -135,12 +147,13 @@ func TestVerifyNamespaceOwner(t *testing.T) {
 						Content: github.Ptr(asJSONString(namespace.Namespace{Owners: []namespace.Owner{{ID: 1, Type: namespace.UserType}}})),
 					}, nil, nil, nil)
 
-				Expect(owner.VerifyNamespaceOwner(tk, o, r, s)).To(Succeed())
+				Expect(owner.VerifyNamespaceOwner(tk, g, nil, o, r, s)).To(Succeed())
 			})
 		})
 
 		context("organization-owned namespace", func() {
 			it.Before(func() {
+				tk.On("GetInput", "issue").Return("", false)
 				tk.On("GetInput", "add-if-missing").Return("", false)
 				tk.On("GetInputList", "blocked_namespaces").Return([]string{""}, false)
 				r.On("GetContents", mock.Anything, "test-owner", "test-repository", filepath.Join("v1", "test-namespace.json"), rOpts).
@@ -153,7 +166,7 @@ func TestVerifyNamespaceOwner(t *testing.T) {
 				o.On("List", mock.Anything, "test-user", mock.Anything).
 					Return([]*github.Organization{}, &github.Response{}, nil)
 
-				Expect(owner.VerifyNamespaceOwner(tk, o, r, s)).
+				Expect(owner.VerifyNamespaceOwner(tk, g, nil, o, r, s)).
 					To(MatchError("::error ::test-user is not an owner of test-namespace"))
 			})
 
@@ -161,7 +174,44 @@ func TestVerifyNamespaceOwner(t *testing.T) {
 				o.On("List", mock.Anything, "test-user", mock.Anything).
 					Return([]*github.Organization{{ID: github.Ptr(int64(1))}}, &github.Response{}, nil)
 
-				Expect(owner.VerifyNamespaceOwner(tk, o, r, s)).To(Succeed())
+				Expect(owner.VerifyNamespaceOwner(tk, g, nil, o, r, s)).To(Succeed())
+			})
+		})
+
+		context("issue is set", func() {
+			it.Before(func() {
+				tk.On("GetInput", "issue").Return(asJSONString(github.Issue{
+					Number:        github.Ptr(1),
+					RepositoryURL: github.Ptr("https://api.github.com/repos/test-owner/test-index"),
+				}), true)
+				tk.On("GetInput", "add-if-missing").Return("", false)
+				tk.On("GetInputList", "blocked_namespaces").Return([]string{"test-owner"}, false)
+			})
+
+			it("reports problems to issue", func() {
+				r.On("GetContents", mock.Anything, "test-owner", "test-repository", filepath.Join("v1", "test-namespace.json"), rOpts).
+					Return(nil, nil, &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, nil)
+				i.On("CreateComment", mock.Anything, "test-owner", "test-index", 1, &github.IssueComment{
+					Body: github.Ptr("This request could not be processed:\n\n* **invalid namespace test-namespace**\n  The namespace must be registered before buildpacks can be added to it.\n"),
+				}).Return(&github.IssueComment{}, nil, nil)
+				i.On("AddLabelsToIssue", mock.Anything, "test-owner", "test-index", 1, []string{index.RequestFailureLabel}).
+					Return(nil, nil, nil)
+
+				Expect(owner.VerifyNamespaceOwner(tk, g, i, o, r, s)).
+					To(MatchError("::error ::invalid namespace test-namespace"))
+				i.AssertExpectations(t)
+			})
+
+			it("does not report without issues service", func() {
+				r.On("GetContents", mock.Anything, "test-owner", "test-repository", filepath.Join("v1", "test-namespace.json"), rOpts).
+					Return(&github.RepositoryContent{
+						Content: github.Ptr(asJSONString(namespace.Namespace{Owners: []namespace.Owner{{ID: 2, Type: namespace.UserType}}})),
+					}, nil, nil, nil)
+				o.On("List", mock.Anything, "test-user", mock.Anything).
+					Return([]*github.Organization{}, &github.Response{}, nil)
+
+				Expect(owner.VerifyNamespaceOwner(tk, g, nil, o, r, s)).
+					To(MatchError("::error ::test-user is not an owner of test-namespace"))
 			})
 		})
 	}, spec.Report(report.Terminal{}))
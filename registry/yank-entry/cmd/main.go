@@ -21,23 +21,17 @@ import (
 	"os"
 	"time"
 
-	"github.com/google/go-github/v89/github"
 	"gopkg.in/retry.v1"
 
 	"github.com/buildpacks/github-actions/internal/toolkit"
+	"github.com/buildpacks/github-actions/registry/internal/credentials"
 	entry "github.com/buildpacks/github-actions/registry/yank-entry"
 )
 
 func main() {
 	tk := &toolkit.DefaultToolkit{}
 
-	t, ok := tk.GetInput("token")
-	if !ok {
-		fmt.Println(toolkit.FailedError("token must be specified"))
-		os.Exit(1)
-	}
-
-	gh, err := github.NewClient(github.WithAuthToken(t))
+	gh, err := credentials.NewClient(tk)
 	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
@@ -51,7 +45,7 @@ func main() {
 		},
 	)
 
-	if err := entry.YankEntry(tk, gh.Repositories, strategy); err != nil {
+	if err := entry.YankEntry(tk, gh.Git, gh.PullRequests, gh.Repositories, strategy); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
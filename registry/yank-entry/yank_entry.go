@@ -18,23 +18,29 @@ package entry
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
-	"github.com/google/go-github/v89/github"
+	"github.com/Masterminds/semver/v3"
 	"gopkg.in/retry.v1"
 
 	"github.com/buildpacks/github-actions/internal/toolkit"
+	"github.com/buildpacks/github-actions/registry/internal/commit"
 	"github.com/buildpacks/github-actions/registry/internal/index"
 	"github.com/buildpacks/github-actions/registry/internal/services"
 )
 
-func YankEntry(tk toolkit.Toolkit, repositories services.RepositoriesService, strategy retry.Strategy) error {
+func YankEntry(tk toolkit.Toolkit, git services.GitService, pulls services.PullRequestsService, repositories services.RepositoriesService, strategy retry.Strategy) error {
 	c, err := parseConfig(tk)
 	if err != nil {
 		return err
 	}
 
+	committer := commit.Committer{Config: c.Commit, Git: git, PullRequests: pulls, Repositories: repositories}
+
 	file := index.Path(c.Namespace, c.Name)
 
 	for a := retry.Start(strategy, nil); a.Next(); {
@@ -55,34 +61,109 @@ func YankEntry(tk toolkit.Toolkit, repositories services.RepositoriesService, st
 			return toolkit.FailedErrorf("unable to unmarshal entries\n%w", err)
 		}
 
-		i := indexOf(entries, c.Namespace, c.Version)
-		if i == nil {
-			return toolkit.FailedErrorf("index %s already does not have namespace %s and version %s", c.Name, c.Namespace, c.Version)
+		matches, err := match(entries, c)
+		if err != nil {
+			return err
 		}
 
-		entries[*i].Yanked = true
+		var versions []string
+		for _, i := range matches {
+			if c.Deprecate {
+				entries[i].Deprecated = true
+			} else {
+				entries[i].Yanked = true
+			}
+
+			if c.Reason != "" {
+				entries[i].Reason = c.Reason
+			}
+
+			if c.Replacement != "" {
+				entries[i].Replacement = c.Replacement
+			}
+
+			versions = append(versions, entries[i].Version)
+		}
 
 		s, err = index.MarshalEntries(entries)
 		if err != nil {
 			return toolkit.FailedErrorf("unable to marshal entries\n%w", err)
 		}
 
-		if _, resp, err := repositories.CreateFile(context.Background(), c.Owner, c.Repository, file, &github.RepositoryContentFileOptions{
-			Author: &github.CommitAuthor{
-				Name:  github.Ptr("buildpacks-bot"),
-				Email: github.Ptr("cncf-buildpacks-maintainers@lists.cncf.io"),
-			},
-			Message: github.Ptr(fmt.Sprintf("YANK %s/%s@%s", c.Namespace, c.Name, c.Version)),
-			SHA:     content.SHA,
+		f := commit.File{
+			Path:    file,
 			Content: []byte(s),
-		}); resp != nil && resp.StatusCode == http.StatusConflict {
+			SHA:     content.SHA,
+		}
+		message := c.Commit.FormatMessage(commit.Values{
+			Namespace: c.Namespace,
+			Name:      c.Name,
+			Version:   c.target(),
+			URL:       c.URL,
+		})
+
+		if !c.PullRequest.Enabled {
+			if err := committer.Commit(context.Background(), c.Owner, c.Repository, f, message); errors.Is(err, commit.ErrConflict) {
+				tk.Warning("retrying index update after conflict")
+				continue
+			} else if err != nil {
+				return toolkit.FailedErrorf("unable to create index\n%w", err)
+			}
+
+			if c.Deprecate {
+				fmt.Printf("Deprecated %s/%s@%s\n", c.Namespace, c.Name, strings.Join(versions, ", "))
+			} else {
+				fmt.Printf("Yanked %s/%s@%s\n", c.Namespace, c.Name, strings.Join(versions, ", "))
+			}
+			tk.SetOutput("versions", strings.Join(versions, ","))
+			return nil
+		}
+
+		suffix := versions[0]
+		if len(versions) > 1 {
+			suffix = fmt.Sprintf("%s-to-%s", versions[0], versions[len(versions)-1])
+		}
+
+		body := fmt.Sprintf("Yanks `%s/%s@%s` from the index.", c.Namespace, c.Name, c.target())
+		branch := fmt.Sprintf("yank/%s/%s/%s", c.Namespace, c.Name, suffix)
+		if c.Deprecate {
+			body = fmt.Sprintf("Deprecates `%s/%s@%s` in the index.", c.Namespace, c.Name, c.target())
+			branch = fmt.Sprintf("deprecate/%s/%s/%s", c.Namespace, c.Name, suffix)
+		}
+		if c.Range != "" {
+			body = fmt.Sprintf("%s\n\nAffected versions: `%s`", body, strings.Join(versions, "`, `"))
+		}
+		if c.Reason != "" {
+			body = fmt.Sprintf("%s\n\nReason: %s", body, c.Reason)
+		}
+		if c.Replacement != "" {
+			body = fmt.Sprintf("%s\n\nReplaced by `%s/%s@%s`.", body, c.Namespace, c.Name, c.Replacement)
+		}
+		if c.URL != "" {
+			body = fmt.Sprintf("%s\n\nRequested in %s", body, c.URL)
+		}
+
+		pr, err := committer.Propose(context.Background(), c.Owner, c.Repository, f, message, commit.PullRequest{
+			Branch: branch,
+			Title:  strings.SplitN(message, "\n", 2)[0],
+			Body:   body,
+		})
+		if errors.Is(err, commit.ErrConflict) {
 			tk.Warning("retrying index update after conflict")
 			continue
 		} else if err != nil {
-			return toolkit.FailedErrorf("unable to create index\n%w", err)
+			return toolkit.FailedErrorf("unable to propose index update\n%w", err)
+		}
+
+		fmt.Printf("Proposed %s/%s@%s in %s\n", c.Namespace, c.Name, c.target(), pr.GetHTMLURL())
+		tk.SetOutput("pull-request-url", pr.GetHTMLURL())
+		tk.SetOutput("pull-request-number", strconv.Itoa(pr.GetNumber()))
+		tk.SetOutput("versions", strings.Join(versions, ","))
+
+		if c.PullRequest.Wait {
+			return commit.WaitForMerge(c.Owner, c.Repository, pr.GetNumber(), pr.GetHTMLURL(), tk, pulls, c.PullRequest.Strategy())
 		}
 
-		fmt.Printf("Yanked %s/%s@%s\n", c.Namespace, c.Name, c.Version)
 		return nil
 	}
 
@@ -90,17 +171,35 @@ func YankEntry(tk toolkit.Toolkit, repositories services.RepositoriesService, st
 }
 
 type config struct {
-	Owner      string
-	Repository string
-	Namespace  string
-	Name       string
-	Version    string
+	Owner       string
+	Repository  string
+	Namespace   string
+	Name        string
+	Version     string
+	Range       string
+	Force       bool
+	URL         string
+	Deprecate   bool
+	Reason      string
+	Replacement string
+	Commit      commit.Config
+	PullRequest commit.PullRequestConfig
+	constraint  *semver.Constraints
+}
+
+func (c config) target() string {
+	if c.Range != "" {
+		return c.Range
+	}
+
+	return c.Version
 }
 
 func parseConfig(tk toolkit.Toolkit) (config, error) {
 	var (
-		c  config
-		ok bool
+		c   config
+		ok  bool
+		err error
 	)
 
 	c.Owner, ok = tk.GetInput("owner")
@@ -123,14 +222,110 @@ func parseConfig(tk toolkit.Toolkit) (config, error) {
 		return config{}, toolkit.FailedError("name must be set")
 	}
 
-	c.Version, ok = tk.GetInput("version")
-	if !ok {
-		return config{}, toolkit.FailedError("version must be set")
+	c.Version, _ = tk.GetInput("version")
+
+	if s, ok := tk.GetInput("range"); ok && s != "" {
+		c.Range = s
+
+		c.constraint, err = semver.NewConstraint(s)
+		if err != nil {
+			return config{}, toolkit.FailedErrorf("invalid range %s\n%w", s, err)
+		}
+	}
+
+	if c.Version == "" && c.Range == "" {
+		return config{}, toolkit.FailedError("version or range must be set")
+	}
+
+	if s, ok := tk.GetInput("force"); ok {
+		if t, err := strconv.ParseBool(s); err == nil {
+			c.Force = t
+		}
+	}
+
+	if s, ok := tk.GetInput("request-url"); ok {
+		c.URL = s
+	}
+
+	if s, ok := tk.GetInput("deprecate"); ok {
+		if t, err := strconv.ParseBool(s); err == nil {
+			c.Deprecate = t
+		}
+	}
+
+	if s, ok := tk.GetInput("reason"); ok {
+		c.Reason = s
+	}
+
+	if s, ok := tk.GetInput("replacement-version"); ok {
+		c.Replacement = s
+	}
+
+	message := "YANK {ns}/{name}@{version}"
+	if c.Deprecate {
+		message = "DEPRECATE {ns}/{name}@{version}"
+	}
+
+	c.Commit, err = commit.ParseConfig(tk, message)
+	if err != nil {
+		return config{}, err
+	}
+
+	c.PullRequest, err = commit.ParsePullRequestConfig(tk)
+	if err != nil {
+		return config{}, err
 	}
 
 	return c, nil
 }
 
+func match(entries []index.Entry, c config) ([]int, error) {
+	if c.constraint == nil {
+		i := indexOf(entries, c.Namespace, c.Version)
+		if i == nil {
+			return nil, toolkit.FailedErrorf("index %s already does not have namespace %s and version %s", c.Name, c.Namespace, c.Version)
+		}
+
+		return []int{*i}, nil
+	}
+
+	var (
+		matches []int
+		total   int
+	)
+
+	// versions that are already yanked or deprecated are neither updated again nor considered by the force check
+	for i, e := range entries {
+		if e.Namespace != c.Namespace || e.Yanked || (c.Deprecate && e.Deprecated) {
+			continue
+		}
+		total++
+
+		v, err := semver.NewVersion(e.Version)
+		if err != nil {
+			continue
+		}
+
+		if c.constraint.Check(v) {
+			matches = append(matches, i)
+		}
+	}
+
+	if len(matches) == 0 {
+		state := "yanked"
+		if c.Deprecate {
+			state = "deprecated"
+		}
+		return nil, toolkit.FailedErrorf("index %s has no versions in namespace %s matching %s that are not already %s", c.Name, c.Namespace, c.Range, state)
+	}
+
+	if len(matches) == total && !c.Force {
+		return nil, toolkit.FailedErrorf("range %s matches every version of %s/%s, set force to yank all of them", c.Range, c.Namespace, c.Name)
+	}
+
+	return matches, nil
+}
+
 func indexOf(entries []index.Entry, namespace string, version string) *int {
 	for i, e := range entries {
 		if e.Namespace == namespace && e.Version == version {
@@ -42,6 +42,8 @@ func TestYankEntry(t *testing.T) {
 			Expect           = NewWithT(t).Expect
 			ExpectWithOffset = NewWithT(t).ExpectWithOffset
 
+			g     = &services.MockGitService{}
+			p     = &services.MockPullRequestsService{}
 			r     = &services.MockRepositoriesService{}
 			rOpts *github.RepositoryContentGetOptions
 			s     = retry.LimitCount(2, retry.Regular{Min: 2})
@@ -61,21 +63,44 @@ func TestYankEntry(t *testing.T) {
 			tk.On("GetInput", "namespace").Return("test-namespace", true)
 			tk.On("GetInput", "name").Return("test-name", true)
 			tk.On("GetInput", "version").Return("test-version", true)
+			tk.On("GetInput", "request-url").Return("", false)
+			tk.On("GetInput", "author-name").Return("", false)
+			tk.On("GetInput", "author-email").Return("", false)
+			tk.On("GetInput", "committer-name").Return("", false)
+			tk.On("GetInput", "committer-email").Return("", false)
+			tk.On("GetInput", "commit-message").Return("", false)
+			tk.On("GetInput", "signing-key").Return("", false)
+			tk.On("GetInput", "pull-request").Return("", false)
+			tk.On("GetInput", "wait-for-merge").Return("", false)
+			tk.On("GetInput", "merge-timeout").Return("", false)
 		})
 
 		context("index does not exist", func() {
 			it.Before(func() {
+				tk.On("GetInput", "deprecate").Return("", false)
+				tk.On("GetInput", "reason").Return("", false)
+				tk.On("GetInput", "replacement-version").Return("", false)
+				tk.On("GetInput", "range").Return("", false)
+				tk.On("GetInput", "force").Return("", false)
 				r.On("GetContents", mock.Anything, "test-owner", "test-repository", filepath.Join("te", "st", "test-namespace_test-name"), rOpts).
 					Return(nil, nil, &github.Response{Response: &http.Response{StatusCode: http.StatusNotFound}}, nil)
 			})
 
 			it("fails if index does not exist", func() {
-				Expect(entry.YankEntry(tk, r, s)).
+				Expect(entry.YankEntry(tk, g, p, r, s)).
 					To(MatchError("::error ::index test-name does not exist"))
 			})
 		})
 
 		context("index does exist", func() {
+			it.Before(func() {
+				tk.On("GetInput", "deprecate").Return("", false)
+				tk.On("GetInput", "reason").Return("", false)
+				tk.On("GetInput", "replacement-version").Return("", false)
+				tk.On("GetInput", "range").Return("", false)
+				tk.On("GetInput", "force").Return("", false)
+			})
+
 			it("fails if version does not exist", func() {
 				r.On("GetContents", mock.Anything, "test-owner", "test-repository", filepath.Join("te", "st", "test-namespace_test-name"), rOpts).
 					Return(&github.RepositoryContent{
@@ -88,7 +113,7 @@ func TestYankEntry(t *testing.T) {
 						SHA: github.Ptr("test-sha"),
 					}, nil, nil, nil)
 
-				Expect(entry.YankEntry(tk, r, s)).
+				Expect(entry.YankEntry(tk, g, p, r, s)).
 					To(MatchError("::error ::index test-name already does not have namespace test-namespace and version test-version"))
 			})
 
@@ -123,8 +148,192 @@ func TestYankEntry(t *testing.T) {
 					SHA: github.Ptr("test-sha"),
 				}).
 					Return(nil, nil, nil)
+				tk.On("SetOutput", "versions", "test-version")
+
+				Expect(entry.YankEntry(tk, g, p, r, s)).To(Succeed())
+			})
+		})
+
+		context("deprecate", func() {
+			it.Before(func() {
+				tk.On("GetInput", "deprecate").Return("true", true)
+				tk.On("GetInput", "reason").Return("test-reason", true)
+				tk.On("GetInput", "replacement-version").Return("test-replacement", true)
+				tk.On("GetInput", "range").Return("", false)
+				tk.On("GetInput", "force").Return("", false)
+				tk.On("SetOutput", "versions", "test-version")
+			})
+
+			it("deprecates entry with reason and replacement", func() {
+				r.On("GetContents", mock.Anything, "test-owner", "test-repository", filepath.Join("te", "st", "test-namespace_test-name"), rOpts).
+					Return(&github.RepositoryContent{
+						Content: github.Ptr(asJSONString(index.Entry{
+							Namespace: "test-namespace",
+							Name:      "test-name",
+							Version:   "test-version",
+							Address:   "test-address",
+						})),
+						SHA: github.Ptr("test-sha"),
+					}, nil, nil, nil)
+
+				r.On("CreateFile", mock.Anything, "test-owner", "test-repository", filepath.Join("te", "st", "test-namespace_test-name"), &github.RepositoryContentFileOptions{
+					Author: &github.CommitAuthor{
+						Name:  github.Ptr("buildpacks-bot"),
+						Email: github.Ptr("cncf-buildpacks-maintainers@lists.cncf.io"),
+					},
+					Message: github.Ptr("DEPRECATE test-namespace/test-name@test-version"),
+					Content: []byte(fmt.Sprintf(
+						"%s\n",
+						asJSONString(index.Entry{
+							Namespace:   "test-namespace",
+							Name:        "test-name",
+							Version:     "test-version",
+							Address:     "test-address",
+							Deprecated:  true,
+							Reason:      "test-reason",
+							Replacement: "test-replacement",
+						}),
+					)),
+					SHA: github.Ptr("test-sha"),
+				}).
+					Return(nil, nil, nil)
+
+				Expect(entry.YankEntry(tk, g, p, r, s)).To(Succeed())
+			})
+		})
+
+		context("range", func() {
+			it.Before(func() {
+				tk.On("GetInput", "deprecate").Return("", false)
+				tk.On("GetInput", "reason").Return("", false)
+				tk.On("GetInput", "replacement-version").Return("", false)
+
+				r.On("GetContents", mock.Anything, "test-owner", "test-repository", filepath.Join("te", "st", "test-namespace_test-name"), rOpts).
+					Return(&github.RepositoryContent{
+						Content: github.Ptr(fmt.Sprintf("%s\n%s\n%s\n",
+							asJSONString(index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "1.0.0", Address: "test-address-1"}),
+							asJSONString(index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "1.4.1", Address: "test-address-2"}),
+							asJSONString(index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "1.4.2", Address: "test-address-3"}),
+						)),
+						SHA: github.Ptr("test-sha"),
+					}, nil, nil, nil)
+			})
+
+			context("matching some versions", func() {
+				it.Before(func() {
+					tk.On("GetInput", "range").Return(">=1.0.0 <1.4.2", true)
+					tk.On("GetInput", "force").Return("", false)
+				})
+
+				it("yanks every matching version", func() {
+					r.On("CreateFile", mock.Anything, "test-owner", "test-repository", filepath.Join("te", "st", "test-namespace_test-name"), &github.RepositoryContentFileOptions{
+						Author: &github.CommitAuthor{
+							Name:  github.Ptr("buildpacks-bot"),
+							Email: github.Ptr("cncf-buildpacks-maintainers@lists.cncf.io"),
+						},
+						Message: github.Ptr("YANK test-namespace/test-name@>=1.0.0 <1.4.2"),
+						Content: []byte(fmt.Sprintf("%s\n%s\n%s\n",
+							asJSONString(index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "1.0.0", Address: "test-address-1", Yanked: true}),
+							asJSONString(index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "1.4.1", Address: "test-address-2", Yanked: true}),
+							asJSONString(index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "1.4.2", Address: "test-address-3"}),
+						)),
+						SHA: github.Ptr("test-sha"),
+					}).
+						Return(nil, nil, nil)
+					tk.On("SetOutput", "versions", "1.0.0,1.4.1")
+
+					Expect(entry.YankEntry(tk, g, p, r, s)).To(Succeed())
+				})
+			})
+
+			context("matching every version", func() {
+				it.Before(func() {
+					tk.On("GetInput", "range").Return(">=1.0.0", true)
+				})
+
+				it("fails if not forced", func() {
+					tk.On("GetInput", "force").Return("", false)
+
+					Expect(entry.YankEntry(tk, g, p, r, s)).
+						To(MatchError("::error ::range >=1.0.0 matches every version of test-namespace/test-name, set force to yank all of them"))
+				})
+
+				it("yanks every version if forced", func() {
+					tk.On("GetInput", "force").Return("true", true)
+
+					r.On("CreateFile", mock.Anything, "test-owner", "test-repository", filepath.Join("te", "st", "test-namespace_test-name"), &github.RepositoryContentFileOptions{
+						Author: &github.CommitAuthor{
+							Name:  github.Ptr("buildpacks-bot"),
+							Email: github.Ptr("cncf-buildpacks-maintainers@lists.cncf.io"),
+						},
+						Message: github.Ptr("YANK test-namespace/test-name@>=1.0.0"),
+						Content: []byte(fmt.Sprintf("%s\n%s\n%s\n",
+							asJSONString(index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "1.0.0", Address: "test-address-1", Yanked: true}),
+							asJSONString(index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "1.4.1", Address: "test-address-2", Yanked: true}),
+							asJSONString(index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "1.4.2", Address: "test-address-3", Yanked: true}),
+						)),
+						SHA: github.Ptr("test-sha"),
+					}).
+						Return(nil, nil, nil)
+					tk.On("SetOutput", "versions", "1.0.0,1.4.1,1.4.2")
+
+					Expect(entry.YankEntry(tk, g, p, r, s)).To(Succeed())
+				})
+			})
+		})
+
+		context("range with already yanked versions", func() {
+			it.Before(func() {
+				tk.On("GetInput", "deprecate").Return("", false)
+				tk.On("GetInput", "reason").Return("", false)
+				tk.On("GetInput", "replacement-version").Return("", false)
+				tk.On("GetInput", "force").Return("", false)
+
+				r.On("GetContents", mock.Anything, "test-owner", "test-repository", filepath.Join("te", "st", "test-namespace_test-name"), rOpts).
+					Return(&github.RepositoryContent{
+						Content: github.Ptr(fmt.Sprintf("%s\n%s\n%s\n",
+							asJSONString(index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "1.0.0", Address: "test-address-1", Yanked: true}),
+							asJSONString(index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "1.4.1", Address: "test-address-2"}),
+							asJSONString(index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "1.4.2", Address: "test-address-3"}),
+						)),
+						SHA: github.Ptr("test-sha"),
+					}, nil, nil, nil)
+			})
+
+			it("requires force if the range matches every version that is not yanked", func() {
+				tk.On("GetInput", "range").Return(">=1.4.0", true)
+
+				Expect(entry.YankEntry(tk, g, p, r, s)).
+					To(MatchError("::error ::range >=1.4.0 matches every version of test-namespace/test-name, set force to yank all of them"))
+			})
+
+			it("does not yank versions again", func() {
+				tk.On("GetInput", "range").Return("<1.4.2", true)
+
+				r.On("CreateFile", mock.Anything, "test-owner", "test-repository", filepath.Join("te", "st", "test-namespace_test-name"), &github.RepositoryContentFileOptions{
+					Author: &github.CommitAuthor{
+						Name:  github.Ptr("buildpacks-bot"),
+						Email: github.Ptr("cncf-buildpacks-maintainers@lists.cncf.io"),
+					},
+					Message: github.Ptr("YANK test-namespace/test-name@<1.4.2"),
+					Content: []byte(fmt.Sprintf("%s\n%s\n%s\n",
+						asJSONString(index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "1.0.0", Address: "test-address-1", Yanked: true}),
+						asJSONString(index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "1.4.1", Address: "test-address-2", Yanked: true}),
+						asJSONString(index.Entry{Namespace: "test-namespace", Name: "test-name", Version: "1.4.2", Address: "test-address-3"}),
+					)),
+					SHA: github.Ptr("test-sha"),
+				}).
+					Return(nil, nil, nil)
+				tk.On("SetOutput", "versions", "1.4.1")
+
+				Expect(entry.YankEntry(tk, g, p, r, s)).To(Succeed())
+			})
+
+			it("fails if every matching version is already yanked", func() {
+				tk.On("GetInput", "range").Return("<1.4.0", true)
 
-				Expect(entry.YankEntry(tk, r, s)).To(Succeed())
+				Expect(entry.YankEntry(tk, g, p, r, s)).
+					To(MatchError("::error ::index test-name has no versions in namespace test-namespace matching <1.4.0 that are not already yanked"))
 			})
 		})
 	}, spec.Report(report.Terminal{}))